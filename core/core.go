@@ -19,6 +19,7 @@ import (
 	"git.ignitelabs.net/janos/core/sys/given"
 	"git.ignitelabs.net/janos/core/sys/given/format"
 	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/shutdown"
 )
 
 func init() {
@@ -45,10 +46,10 @@ func init() {
 		fmt.Println("  ╰" + randomDash() + "⬎")
 	}
 
-	Deferrals() <- func(wg *sync.WaitGroup) {
+	OnShutdown(shutdown.Cleanup, "atlas", func(wg *sync.WaitGroup) {
 		atlas.Cleanup()
 		wg.Done()
-	}
+	})
 }
 
 var ModuleName = "core"
@@ -77,9 +78,7 @@ func Describe(description string, article ...string) {
 	}
 	Name.Description = description
 	described = true
-	if !rec.Silent {
-		fmt.Printf("[core] %v is %v \"%v\"\n", Name.Name, descriptionArticle, description)
-	}
+	rec.Printf(ModuleName, "%v is %v \"%v\"\n", Name.Name, descriptionArticle, description)
 }
 
 // Ref creates an inline "dead reference" of a value type.  In many places, JanOS allows you to provide a reference
@@ -97,11 +96,29 @@ func Ref[T any](val T) *T {
 
 // Deferrals are where you can send actions you wish to be fired just before the JanOS instance shuts down.  This is useful
 // for performing global 'cleanup' operations.
+//
+// NOTE: Deferrals run during the shutdown.Cleanup phase - if you'd like to act during another phase, or have your
+// action reported by name should it fail to finish in time, see OnShutdown.
+//
+// NOTE: Unlike a cortex's synaptic channels, deferrals take no overflow policy - dropping one would silently skip its
+// cleanup, so sends block once atlas.SynapticChannelLimit deferrals are waiting.  OnShutdown has no such limit.
 func Deferrals() chan<- func(group *sync.WaitGroup) {
 	return deferrals
 }
 
-var deferrals = make(chan func(*sync.WaitGroup), 1<<16)
+var deferrals = make(chan func(*sync.WaitGroup), atlas.SynapticChannelLimit)
+
+var shutdownHooks shutdown.Hooks
+
+// OnShutdown registers a named action to run during the provided phase of shutdown.  Just like a deferral, the action
+// must call wg.Done() once it has finished - the name is how it's reported should it fail to finish in time.
+//
+// The returned function unregisters the action, which should be called once it's no longer relevant.
+//
+// See ShutdownNow
+func OnShutdown(phase shutdown.Phase, named string, action func(wg *sync.WaitGroup)) (remove func()) {
+	return shutdownHooks.Add(phase, named, action)
+}
 
 // ShutdownLock is a part of the ShutdownCondition system.  If you would like to wait for a broadcast 'shutdown' message,
 // please use ShutdownCondition.  For example:
@@ -124,60 +141,59 @@ var ShutdownCondition = &sync.Cond{L: &ShutdownLock}
 //
 // NOTE: If you don't know a proper exit code but are indicating an issue occurred, please use the catch-all exit code '1'.
 func Shutdown(period time.Duration, exitCode ...int) {
-	if !rec.Silent {
-		fmt.Printf("[core] %v instance shutting down in %v\n", Name.Name, period)
-	}
+	rec.Printf(ModuleName, "%v instance shutting down in %v\n", Name.Name, period)
 	time.Sleep(period)
 	ShutdownNow(exitCode...)
 }
 
-// ShutdownNow immediately sets Alive to false, then pauses for a second before calling os.Exit. You may optionally
-// provide an OS exit code, otherwise '0' is implied.
+// ShutdownNow immediately sets Alive to false, then shuts the instance down through each shutdown.Phase in order -
+// running the hooks registered through OnShutdown, with any Deferrals joining the shutdown.Cleanup phase - before
+// calling os.Exit.  You may optionally provide an OS exit code, otherwise '0' is implied.
+//
+// Each phase is bounded by atlas.ShutdownTimeout - any hooks or deferrals which fail to finish in time are reported
+// by name and abandoned, so a misbehaving deferral can never hang the process.
 //
 // NOTE: If you don't know a proper exit code but are indicating an issue occurred, please use the "catch-all" exit code of '1'.
 func ShutdownNow(exitCode ...int) {
-	if !rec.Silent {
-		fmt.Printf("\n[core] %v instance shutting down\n", Name.Name)
-	}
+	rec.Printf(ModuleName, "%v instance shutting down\n", Name.Name)
 	alive = false
+	ShutdownCondition.Broadcast()
+
+	ran := false
+	for _, phase := range shutdown.Phases {
+		hooks := shutdownHooks.Take(phase)
+		if phase == shutdown.Cleanup {
+			for len(deferrals) > 0 {
+				hooks = append(hooks, shutdown.Hook{Named: "deferral", Action: <-deferrals})
+			}
+		}
 
-	wg := &sync.WaitGroup{}
+		count := len(hooks)
+		if count == 0 {
+			continue
+		}
+		ran = true
 
-	count := len(deferrals)
-	if count > 0 {
 		if count > 1 {
-			if !rec.Silent {
-				fmt.Printf("[core] %v running %d deferrals\n", Name.Name, count)
-			}
+			rec.Printf(ModuleName, "%v running %d %v hooks\n", Name.Name, count, phase)
 		} else {
-			if !rec.Silent {
-				fmt.Printf("[core] %v running %d deferral\n", Name.Name, count)
-			}
+			rec.Printf(ModuleName, "%v running %d %v hook\n", Name.Name, count, phase)
+		}
+
+		onPanic := func(named string, r any) {
+			rec.Errorf(ModuleName, "%v %s error: %v\n", Name.Name, named, r)
 		}
-		for len(deferrals) > 0 {
-			deferFn := <-deferrals
-			wg.Add(1)
-			go func() {
-				defer func() {
-					if r := recover(); r != nil {
-						if !rec.Silent {
-							fmt.Printf("[core] %v deferral error: %v\n", Name.Name, r)
-						}
-						wg.Done()
-					}
-				}()
-
-				deferFn(wg)
-			}()
+		unfinished := shutdown.Await(shutdown.Deadline(atlas.ShutdownTimeout), onPanic, hooks...)
+		if len(unfinished) > 0 {
+			rec.Errorf(ModuleName, "%v %v phase timed out after %v waiting on: %s\n", Name.Name, phase, atlas.ShutdownTimeout, strings.Join(unfinished, ", "))
 		}
-		wg.Wait()
+	}
 
-		if !rec.Silent {
-			if described {
-				fmt.Printf("[core] signing off — \"%v, %v\"\n", Name.Name, Name.Description)
-			} else {
-				fmt.Printf("[core] signing off — \"%v\"\n", Name.Name)
-			}
+	if ran {
+		if described {
+			rec.Printf(ModuleName, "signing off — \"%v, %v\"\n", Name.Name, Name.Description)
+		} else {
+			rec.Printf(ModuleName, "signing off — \"%v\"\n", Name.Name)
 		}
 	}
 
@@ -224,9 +240,7 @@ func WhileAlive(fn func(), period ...time.Duration) {
 func KeepAlive(postDelay ...time.Duration) {
 	if len(postDelay) > 0 {
 		deferrals <- func(wg *sync.WaitGroup) {
-			if !rec.Silent {
-				fmt.Printf("[core] %v holding open for %v\n", Name.Name, postDelay[0])
-			}
+			rec.Printf(ModuleName, "%v holding open for %v\n", Name.Name, postDelay[0])
 			time.Sleep(postDelay[0])
 			wg.Done()
 		}
@@ -235,7 +249,6 @@ func KeepAlive(postDelay ...time.Duration) {
 	notify, stop := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill, syscall.SIGTERM)
 	defer stop()
 	<-notify.Done()
-	ShutdownCondition.Broadcast()
 	ShutdownNow()
 }
 
@@ -249,12 +262,10 @@ func HandlePanic(named string, location string, verbose ...bool) {
 	}
 
 	if r := recover(); r != nil {
-		if !rec.Silent {
-			if v {
-				fmt.Printf("[%s] %s panic: %v\n%s", named, location, r, debug.Stack())
-			} else {
-				fmt.Printf("[%s] %s panic: %v\n", named, location, r)
-			}
+		if v {
+			rec.Errorf(named, "%s panic: %v\n%s", location, r, debug.Stack())
+		} else {
+			rec.Errorf(named, "%s panic: %v\n", location, r)
 		}
 	}
 }
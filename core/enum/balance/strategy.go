@@ -0,0 +1,31 @@
+package balance
+
+// A Strategy defines how a load balancer chooses which upstream serves each request.  There are three strategies:
+//
+// 0 - RoundRobin - each upstream is chosen in turn
+//
+// 1 - LeastConnections - the upstream with the fewest requests in flight is chosen
+//
+// 2 - ConsistentHash - requests sharing a key (such as the client's address) are always sent to the same upstream,
+// and only the keys of an upstream which leaves the pool are redistributed
+type Strategy byte
+
+const (
+	RoundRobin Strategy = iota
+	LeastConnections
+	ConsistentHash
+)
+
+// String prints an uppercase one-word representation of the Strategy.
+func (s Strategy) String() string {
+	switch s {
+	case RoundRobin:
+		return "RoundRobin"
+	case LeastConnections:
+		return "LeastConnections"
+	case ConsistentHash:
+		return "ConsistentHash"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,35 @@
+package change
+
+// A Kind defines what happened to a path observed by a file watcher.  There are four kinds:
+//
+// 0 - Create - the path was created, or moved into the watched tree
+//
+// 1 - Modify - the contents of the path were written to
+//
+// 2 - Delete - the path was removed
+//
+// 3 - Rename - the path was moved away - its new name, if still within the watched tree, arrives as a Create
+type Kind byte
+
+const (
+	Create Kind = iota
+	Modify
+	Delete
+	Rename
+)
+
+// String prints an uppercase one-word representation of the Kind.
+func (k Kind) String() string {
+	switch k {
+	case Create:
+		return "Create"
+	case Modify:
+		return "Modify"
+	case Delete:
+		return "Delete"
+	case Rename:
+		return "Rename"
+	default:
+		return "Unknown"
+	}
+}
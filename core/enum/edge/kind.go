@@ -0,0 +1,30 @@
+package edge
+
+// A Kind defines which transitions of a potential an edge detector reacts to.  There are three kinds:
+//
+// 0 - Rising - the potential went from low to high
+//
+// 1 - Falling - the potential went from high to low
+//
+// 2 - Both - the potential changed in either direction
+type Kind byte
+
+const (
+	Rising Kind = iota
+	Falling
+	Both
+)
+
+// String prints an uppercase one-word representation of the Kind.
+func (k Kind) String() string {
+	switch k {
+	case Rising:
+		return "Rising"
+	case Falling:
+		return "Falling"
+	case Both:
+		return "Both"
+	default:
+		return "Unknown"
+	}
+}
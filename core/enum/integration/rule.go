@@ -0,0 +1,26 @@
+package integration
+
+// A Rule defines the numerical method used to integrate temporal data.  There are two rules:
+//
+// 0 - Trapezoidal - each interval is integrated as a straight line between its two moments
+//
+// 1 - Simpson - each pair of intervals is integrated as the parabola through its three moments, which is exact for
+// quadratic signals (and handles irregularly spaced moments)
+type Rule byte
+
+const (
+	Trapezoidal Rule = iota
+	Simpson
+)
+
+// String prints an uppercase one-word representation of the Rule.
+func (r Rule) String() string {
+	switch r {
+	case Trapezoidal:
+		return "Trapezoidal"
+	case Simpson:
+		return "Simpson"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,31 @@
+package interpolation
+
+// A Mode defines how a value is estimated between two recorded moments.  There are three modes:
+//
+// 0 - Step - the value recorded at or before the moment is held until the next is recorded
+//
+// 1 - Linear - a straight line is drawn between the surrounding recorded values
+//
+// 2 - Cubic - a smooth cubic Hermite curve is drawn through the surrounding recorded values, with each value's tangent
+// taken from the parabola through it and its neighbors (handling irregularly spaced moments)
+type Mode byte
+
+const (
+	Step Mode = iota
+	Linear
+	Cubic
+)
+
+// String prints an uppercase one-word representation of the Mode.
+func (m Mode) String() string {
+	switch m {
+	case Step:
+		return "Step"
+	case Linear:
+		return "Linear"
+	case Cubic:
+		return "Cubic"
+	default:
+		return "Unknown"
+	}
+}
@@ -19,3 +19,19 @@ const (
 	Triggered
 	Impulse
 )
+
+// String prints an uppercase one-word representation of the Cycle.
+func (c Cycle) String() string {
+	switch c {
+	case Looping:
+		return "Looping"
+	case Stimulative:
+		return "Stimulative"
+	case Triggered:
+		return "Triggered"
+	case Impulse:
+		return "Impulse"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,26 @@
+package misfire
+
+// A Policy defines how a scheduled potential handles the firings it missed while it wasn't being consulted - for
+// instance, while its cortex was muted or stalled.  There are two policies:
+//
+// 0 - Skip - however many firings were missed, the potential fires once and then waits for the next scheduled firing
+//
+// 1 - CatchUp - the potential fires once per impulse for every firing it missed, until it has caught up
+type Policy byte
+
+const (
+	Skip Policy = iota
+	CatchUp
+)
+
+// String prints an uppercase one-word representation of the Policy.
+func (p Policy) String() string {
+	switch p {
+	case Skip:
+		return "Skip"
+	case CatchUp:
+		return "CatchUp"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,36 @@
+package overflow
+
+// A Policy defines how a synaptic channel behaves when a signal arrives while it's full.  There are four policies:
+//
+// 0 - Block - the sender waits until there's room for the signal
+//
+// 1 - DropNewest - the arriving signal is dropped
+//
+// 2 - DropOldest - the oldest waiting signal is dropped to make room for the arriving one
+//
+// 3 - Coalesce - the arriving signal is merged into any signal already waiting, regardless of capacity - this is
+// intended for signals which carry no value, such as impulse requests, where many waiting signals mean the same as one.
+type Policy byte
+
+const (
+	Block Policy = iota
+	DropNewest
+	DropOldest
+	Coalesce
+)
+
+// String prints an uppercase one-word representation of the Policy.
+func (p Policy) String() string {
+	switch p {
+	case Block:
+		return "Block"
+	case DropNewest:
+		return "DropNewest"
+	case DropOldest:
+		return "DropOldest"
+	case Coalesce:
+		return "Coalesce"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,26 @@
+package restart
+
+// An Escalation defines what a supervisor does once it has exhausted its restart budget.  If the supervisor is itself
+// the child of another supervisor, it always escalates by failing as a child of its parent - otherwise:
+//
+// 0 - Cortex - the cortex the supervisor is wired into is shut down
+//
+// 1 - Process - the entire JanOS instance is shut down through core.ShutdownNow with the supervisor's exit code
+type Escalation byte
+
+const (
+	Cortex Escalation = iota
+	Process
+)
+
+// String prints an uppercase one-word representation of the Escalation.
+func (e Escalation) String() string {
+	switch e {
+	case Cortex:
+		return "Cortex"
+	case Process:
+		return "Process"
+	default:
+		return "Unknown"
+	}
+}
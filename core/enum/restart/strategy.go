@@ -0,0 +1,25 @@
+package restart
+
+// A Strategy defines which children a supervisor restarts when one of them panics.  There are two strategies:
+//
+// 0 - OneForOne - only the child which panicked is restarted
+//
+// 1 - OneForAll - every child is stopped and restarted together, for children which cannot function without each other
+type Strategy byte
+
+const (
+	OneForOne Strategy = iota
+	OneForAll
+)
+
+// String prints an uppercase one-word representation of the Strategy.
+func (s Strategy) String() string {
+	switch s {
+	case OneForOne:
+		return "OneForOne"
+	case OneForAll:
+		return "OneForAll"
+	default:
+		return "Unknown"
+	}
+}
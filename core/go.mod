@@ -2,4 +2,12 @@ module git.ignitelabs.net/janos/core
 
 go 1.25
 
-require golang.org/x/sys v0.38.0
+require (
+	golang.org/x/crypto v0.45.0
+	golang.org/x/sys v0.38.0
+)
+
+require (
+	golang.org/x/net v0.47.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
+)
@@ -38,5 +38,5 @@ func (a Anonymous) From(other Anonymous) Vector {
 
 func (a Anonymous) String() string {
 	// TODO: Write this like the letter vectors output themselves, but without a name
-	return fmt.Sprintf("%v", a)
+	return fmt.Sprintf("%v", map[string]any(a))
 }
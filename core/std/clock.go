@@ -0,0 +1,33 @@
+package std
+
+import "time"
+
+// A Clock provides the passage of time to temporal structures.  By default, everything in JanOS observes the
+// SystemClock - but a cortex (and everything it drives) can be bound to any other clock, such as a VirtualClock.
+type Clock interface {
+	// Now returns the clock's current moment.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current moment on the returned channel.
+	After(d time.Duration) <-chan time.Time
+
+	// Sleep pauses the calling goroutine for at least the provided duration.
+	Sleep(d time.Duration)
+}
+
+// SystemClock is the Clock which observes the operating system's wall clock.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
@@ -6,6 +6,7 @@ import (
 
 	"git.ignitelabs.net/janos/core"
 	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/enum/overflow"
 	"git.ignitelabs.net/janos/core/sys/rec"
 )
 
@@ -14,14 +15,20 @@ import (
 // 0 - The distributed activity should always attempt to activate in the same order
 // 1 - Activations should adjust their activation time automatically when any neuron decays out or is added to the stack
 // 2 - Looping activations should not reactivate if they are still running
+//
+// NOTE: A cluster holds at most its cortex's limit of neurons at once - any further neurons wait in its channels until
+// others decay out, and those channels then behave according to the cluster's Overflow policy.
 type Cluster struct {
-	loop      chan Neural
-	stimulate chan Neural
-	trigger   chan Neural
-	impulse   chan Neural
+	loop      *synapticChannel[Neural]
+	stimulate *synapticChannel[Neural]
+	trigger   *synapticChannel[Neural]
+	impulse   *synapticChannel[Neural]
 
 	neuralCount uint
 
+	// Overflow defines how the cluster's channels behave once they're full - the zero value blocks.
+	Overflow overflow.Policy
+
 	Mute   bool
 	Decay  bool
 	Period *time.Duration // A nil or negative period should be treated as '1 s'
@@ -43,24 +50,53 @@ func (cls *Cluster) sanityCheck() {
 	}
 }
 
+// NOTE: Sending directly into this channel blocks once it's full, regardless of the Overflow policy - see Wire.
 func (cls *Cluster) Loop() chan<- Neural {
 	cls.sanityCheck()
-	return cls.loop
+	return cls.loop.channel
 }
 
+// NOTE: Sending directly into this channel blocks once it's full, regardless of the Overflow policy - see Wire.
 func (cls *Cluster) Stimulate() chan<- Neural {
 	cls.sanityCheck()
-	return cls.stimulate
+	return cls.stimulate.channel
 }
 
+// NOTE: Sending directly into this channel blocks once it's full, regardless of the Overflow policy - see Wire.
 func (cls *Cluster) Trigger() chan<- Neural {
 	cls.sanityCheck()
-	return cls.trigger
+	return cls.trigger.channel
 }
 
+// NOTE: Sending directly into this channel blocks once it's full, regardless of the Overflow policy - see Wire.
 func (cls *Cluster) Impulse() chan<- Neural {
 	cls.sanityCheck()
-	return cls.impulse
+	return cls.impulse.channel
+}
+
+// Wire sends the provided neurons into the channel matching the provided cycle, according to the cluster's Overflow
+// policy.
+func (cls *Cluster) Wire(cycle life.Cycle, neurons ...Neural) {
+	cls.sanityCheck()
+
+	channel := cls.loop
+	switch cycle {
+	case life.Stimulative:
+		channel = cls.stimulate
+	case life.Triggered:
+		channel = cls.trigger
+	case life.Impulse:
+		channel = cls.impulse
+	}
+
+	for _, n := range neurons {
+		channel.send(n, cls.Overflow)
+	}
+}
+
+// Dropped returns the number of neurons the cluster's channels have dropped (or coalesced) due to overflow.
+func (cls *Cluster) Dropped() uint64 {
+	return cls.loop.Dropped() + cls.stimulate.Dropped() + cls.trigger.Dropped() + cls.impulse.Dropped()
 }
 
 func (cls *Cluster) Spark() {
@@ -87,15 +123,16 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 	}
 
 	cls := &Cluster{
-		loop:      make(chan Neural, ctx.limit),
-		stimulate: make(chan Neural, ctx.limit),
-		trigger:   make(chan Neural, ctx.limit),
-		impulse:   make(chan Neural, ctx.limit),
+		loop:      newSynapticChannel[Neural](ctx.limit),
+		stimulate: newSynapticChannel[Neural](ctx.limit),
+		trigger:   newSynapticChannel[Neural](ctx.limit),
+		impulse:   newSynapticChannel[Neural](ctx.limit),
 
 		Period: period,
-		last:   time.Now(),
+		last:   ctx.clock.Now(),
 	}
 	cls.spark = func() {
+		// NOTE: Every endpoint sits in this queue at most once, and loadFn admits no more than ctx.limit of them
 		endpoints := make(chan *endpoint, ctx.limit)
 
 		ctx.Deferrals() <- func(wg *sync.WaitGroup) {
@@ -104,10 +141,12 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 				if cls.neuralCount > 0 {
 					cls.neuralCount--
 				}
-				if end.Cleanup != nil {
-					end.Cleanup(nil)
+				end.Cleanup(nil)
+				end.impulse.decay()
+				select {
+				case end.fire <- nil:
+				default:
 				}
-				end.fire <- nil
 				rec.Verbosef(end.bridgeStr, "decayed\n")
 			}
 			wg.Done()
@@ -117,14 +156,11 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 			receiveFn := func(n Neural) *endpoint {
 				rec.Verbosef(bridge.String(), "wiring axon to neural endpoint '%v'\n", n.Named())
 
+				// NOTE: An endpoint is only fired after leaving the endpoints queue, so it never has more than one pending fire
 				end := &endpoint{
-					Neural: n,
-					fire:   make(chan *Impulse, 1<<16),
-					impulse: &Impulse{
-						Bridge:   append(bridge, n.Named()),
-						Timeline: NewTimeline(),
-						Cortex:   ctx,
-					},
+					Neural:  n,
+					fire:    make(chan *Impulse, 1),
+					impulse: ctx.spawn(append(bridge, n.Named())...),
 				}
 
 				go func() {
@@ -140,8 +176,9 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 						end.running = true
 
 						activation := func() {
+							defer ctx.release()
 							end.Action(impulse)
-							impulse.Timeline.setCompleted(impulse.currentEvent.id, time.Now())
+							impulse.complete(impulse.currentEvent, ctx.clock.Now())
 							impulse.Count++
 							end.running = false
 
@@ -149,9 +186,8 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 								impulse.Decay = true
 							}
 							if impulse.Decay == true {
-								if end.Cleanup != nil {
-									end.Cleanup(impulse)
-								}
+								end.Cleanup(impulse)
+								impulse.decay()
 								cls.neuralCount--
 								rec.Verbosef(impulse.Bridge.String(), "decayed\n")
 								decay = true
@@ -173,21 +209,24 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 				return end
 			}
 
-			for len(cls.loop) > 0 || len(cls.stimulate) > 0 || len(cls.trigger) > 0 || len(cls.impulse) > 0 {
+			for cls.neuralCount < uint(ctx.limit) && (len(cls.loop.channel) > 0 || len(cls.stimulate.channel) > 0 || len(cls.trigger.channel) > 0 || len(cls.impulse.channel) > 0) {
 				select {
-				case n := <-cls.loop:
+				case n := <-cls.loop.channel:
 					endpoints <- receiveFn(n)
-				case n := <-cls.stimulate:
+				case n := <-cls.stimulate.channel:
 					end := receiveFn(n)
 					end.stimulative = true
+					end.impulse.lifecycle = life.Stimulative
 					endpoints <- end
-				case n := <-cls.trigger:
+				case n := <-cls.trigger.channel:
 					end := receiveFn(n)
 					end.triggered = true
+					end.impulse.lifecycle = life.Triggered
 					endpoints <- end
-				case n := <-cls.impulse:
+				case n := <-cls.impulse.channel:
 					end := receiveFn(n)
 					end.impulsed = true
+					end.impulse.lifecycle = life.Impulse
 					endpoints <- end
 				}
 			}
@@ -210,10 +249,10 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 			end.impulse.currentEvent = imp.currentEvent
 
 			if (end.stimulative || !end.running) && end.Potential(end.impulse) {
-				end.impulse.Beat = ctx.beat
+				end.impulse.Beat = uint(ctx.beat.Load())
 				end.impulse.BeatPeriod = ctx.BeatPeriod
-				end.impulse.currentEvent.Activation = time.Now()
-				end.impulse.Timeline.Add(*end.impulse.currentEvent)
+				end.impulse.activate(end.impulse.currentEvent, ctx.clock.Now())
+				ctx.engage()
 				end.fire <- end.impulse
 			}
 		}, func(imp *Impulse) bool {
@@ -228,7 +267,7 @@ func (ctx *Cortex) CreateCluster(named string, period *time.Duration, potential
 				p = *cls.Period
 			}
 
-			now := time.Now()
+			now := ctx.clock.Now()
 			offset := time.Duration(p.Nanoseconds() / int64(cls.neuralCount))
 			if now.After(cls.last.Add(offset)) {
 				cls.last = now
@@ -0,0 +1,43 @@
+package std
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// A Codec translates elements to and from bytes, allowing temporal data to be persisted.  See TemporalBuffer.Persist
+type Codec[T any] interface {
+	Encode(element T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// JSONCodec encodes elements as JSON - only exported fields are preserved.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(element T) ([]byte, error) {
+	return json.Marshal(element)
+}
+
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var element T
+	err := json.Unmarshal(data, &element)
+	return element, err
+}
+
+// GobCodec encodes elements using encoding/gob - only exported fields are preserved.
+//
+// NOTE: Each element is encoded independently, so the type information is repeated within every record.
+type GobCodec[T any] struct{}
+
+func (GobCodec[T]) Encode(element T) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(element)
+	return buffer.Bytes(), err
+}
+
+func (GobCodec[T]) Decode(data []byte) (T, error) {
+	var element T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&element)
+	return element, err
+}
@@ -1,14 +1,20 @@
 package std
 
 import (
+	"context"
+	"errors"
 	"math"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"git.ignitelabs.net/janos/core"
 	"git.ignitelabs.net/janos/core/sys/atlas"
 	"git.ignitelabs.net/janos/core/sys/given/format"
+	"git.ignitelabs.net/janos/core/sys/id"
 	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/shutdown"
 )
 
 // A Cortex represents a source of neural impulses.  It defines the frequency which synaptic activity can fire at.
@@ -25,70 +31,137 @@ type Cortex struct {
 	//
 	// NOTE: Set this to a negative value for an infinite phase =)
 	BeatPeriod int
-	beat       uint
+	beat       atomic.Uint64 // NOTE: beat is atomic, as it's read by every neural goroutine
+	pulses     atomic.Uint64 // NOTE: pulses counts every beat of the cortex, never looping
+
+	// PhaseLock, when set, places the cortex into phase-locked mode - see PhaseLock.
+	PhaseLock *PhaseLock
 
 	inception time.Time
+	clock     Clock
+
+	context context.Context
+	cancel  context.CancelFunc
+
+	// Overflow defines how the cortex's synaptic channels behave once they're full.
+	Overflow Overflow
 
-	synapses chan Synapse
+	synapses *synapticChannel[Synapse]
 	timeline []time.Time
 
-	deferrals    chan func(*sync.WaitGroup)
-	deferralWait *sync.WaitGroup
+	deferrals chan func(*sync.WaitGroup)
 
-	mute         chan any
-	unmute       chan any
-	impulse      chan any
-	shutdown     chan any
-	closed       chan any // NOTE: closed is used to 'close' signal when the cortex is shutting down
-	shutdownWait *sync.WaitGroup
-	hold         *sync.WaitGroup
+	mute     *synapticChannel[any]
+	unmute   *synapticChannel[any]
+	impulse  *synapticChannel[any]
+	shutdown chan any
+	closed   chan any // NOTE: closed is used to 'close' signal when the cortex is shutting down
+	decayed  chan any // NOTE: decayed is closed once the cortex has finished shutting down
+	living   map[*Impulse]struct{}
+	hooks    shutdown.Hooks
+	timeout  time.Duration // NOTE: timeout is the atlas.ShutdownTimeout as of calling Shutdown
+	deadline time.Time     // NOTE: deadline bounds the entire decay, as the instance's Drain hook only waits on it for a single timeout
 
-	alive   bool
+	alive   atomic.Bool // NOTE: alive is atomic, as it's read outside the lock by every neural goroutine
 	created bool
 	running bool
+	sparked bool
 	limit   int
 
+	tracer  atomic.Pointer[tracer]
+	metrics atomic.Pointer[Metrics]
+
+	parent   *Cortex
+	children []*Cortex
+	divisor  uint
+	division uint         // NOTE: division counts the parent's beats - it's only touched by the parent's loop
+	divided  chan float64 // NOTE: divided carries the beats the parent divides down to this cortex, along with its divided frequency
+
+	timed      time.Time // NOTE: timed holds the moment of the most recent timed beat
+	lockStatus PhaseLockStatus
+
 	timeLock sync.Mutex
 	master   sync.Mutex
-	clock    sync.Cond
+	pulse    sync.Cond
+	settled  sync.Cond
+
+	active int // NOTE: active counts the neural goroutines which are still reacting to the current beat
+	parked int // NOTE: parked counts the neural goroutines which are waiting for the next beat
 }
 
+// ErrCortexUncreated is returned when operating on a cortex that wasn't created through NewCortex.
+var ErrCortexUncreated = errors.New("cortices must be created through NewCortex")
+
+// ErrCortexShutdown is returned when attempting to spark or shut down a cortex which has already shut down.
+var ErrCortexShutdown = errors.New("cannot re-spark a cortex after shutdown - please create a new cortex")
+
 // NewCortex creates a new named Cortex limited to the provided number of neural activations.
 //
 // If you'd like a randomly generated name, see given.Random[ format.Format ]
 //
-// NOTE: If no limit is provided, the default is 2¹⁶ - this can generally be ignored for most systems.
+// NOTE: If no limit is provided, atlas.SynapticChannelLimit is used - this can generally be ignored for most systems.
+// The limit applies to every synaptic channel of the cortex, which then behave according to its Overflow policies.
 func NewCortex(named string, synapticLimit ...int) *Cortex {
-	limit := 1 << 16
+	return newCortex(context.Background(), SystemClock, named, synapticLimit...)
+}
+
+// NewCortexWithContext creates a new named Cortex whose life is bound to the provided parent context.  Cancelling
+// the parent will shut the cortex down, and every Impulse it fires carries a Context derived from it.
+//
+// See NewCortex
+func NewCortexWithContext(parent context.Context, named string, synapticLimit ...int) *Cortex {
+	return newCortex(parent, SystemClock, named, synapticLimit...)
+}
+
+// NewCortexWithClock creates a new named Cortex which observes the provided Clock rather than the SystemClock.  Every
+// impulse, timeline, and temporal potential driven by the cortex will observe the same clock.
+//
+// See NewCortex and VirtualClock
+func NewCortexWithClock(named string, clock Clock, synapticLimit ...int) *Cortex {
+	return newCortex(context.Background(), clock, named, synapticLimit...)
+}
+
+func newCortex(parent context.Context, clock Clock, named string, synapticLimit ...int) *Cortex {
+	if parent == nil {
+		parent = context.Background()
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	limit := int(atlas.SynapticChannelLimit)
 	if len(synapticLimit) > 0 {
 		limit = synapticLimit[0]
 	}
 
 	c := &Cortex{
-		Entity:       NewEntity[format.Default](),
-		inception:    time.Now(),
-		synapses:     make(chan Synapse, limit),
-		deferrals:    make(chan func(*sync.WaitGroup), 1<<16),
-		deferralWait: &sync.WaitGroup{},
-		mute:         make(chan any, 1<<16),
-		unmute:       make(chan any, 1<<16),
-		impulse:      make(chan any, 1<<16),
-		shutdown:     make(chan any, 1<<16),
-		closed:       make(chan any, 1<<16),
-		limit:        limit,
-		alive:        true,
-		created:      true,
-	}
-	c.clock = sync.Cond{L: &c.master}
+		Entity:    NewEntity[format.Default](),
+		inception: clock.Now(),
+		clock:     clock,
+		synapses:  newSynapticChannel[Synapse](limit),
+		deferrals: make(chan func(*sync.WaitGroup), limit),
+		mute:      newSynapticChannel[any](limit),
+		unmute:    newSynapticChannel[any](limit),
+		impulse:   newSynapticChannel[any](limit),
+		shutdown:  make(chan any, 1),
+		closed:    make(chan any),
+		decayed:   make(chan any),
+		limit:     limit,
+		created:   true,
+	}
+	c.alive.Store(true)
+	c.pulse = sync.Cond{L: &c.master}
+	c.settled = sync.Cond{L: &c.master}
+	c.context, c.cancel = context.WithCancel(parent)
 	c.Entity.Name = named
-	c.hold = &sync.WaitGroup{}
+	c.living = make(map[*Impulse]struct{})
 
 	rec.Verbosef(core.ModuleName, "%v has created cortex '%s'\n", core.Name.Name, c.Named())
 	return c
 }
 
 func (ctx *Cortex) Phase(frequency float64) float64 {
-	return 1.0 * math.Sin((2*math.Pi*frequency)*(time.Now().Sub(ctx.Inception()).Seconds()))
+	return 1.0 * math.Sin((2*math.Pi*frequency)*(ctx.clock.Now().Sub(ctx.Inception()).Seconds()))
 }
 
 // Optional: return the instantaneous phase angle in [0, 2π)
@@ -96,7 +169,7 @@ func (ctx *Cortex) PhaseAngle(frequency float64) float64 {
 	if frequency <= 0 {
 		return 0
 	}
-	t := time.Since(ctx.inception).Seconds()
+	t := ctx.clock.Now().Sub(ctx.inception).Seconds()
 	theta := 2 * math.Pi * frequency * t
 	// normalize to [0, 2π)
 	theta = math.Mod(theta, 2*math.Pi)
@@ -111,7 +184,7 @@ func (ctx *Cortex) PhaseFraction(frequency float64) float64 {
 	if frequency <= 0 {
 		return 0
 	}
-	t := time.Since(ctx.inception).Seconds()
+	t := ctx.clock.Now().Sub(ctx.inception).Seconds()
 	p := math.Mod(frequency*t, 1.0)
 	if p < 0 {
 		p += 1.0
@@ -121,11 +194,15 @@ func (ctx *Cortex) PhaseFraction(frequency float64) float64 {
 
 // Impulse causes the cortex to fire a single impulse cycle.  Please note this is an asynchronous invocation.
 //
-// NOTE: If your cortex is phase-locked, this will inherently break its ability to track the phase momentarily.
-// This is because phase-locking (at the cortex level) relies on tracking phase relative to the last impulse moment,
-// which shifts when an impulse is fired.
+// NOTE: Without a PhaseLock, timed beats are scheduled relative to the last beat - meaning an impulse shifts the phase
+// of every beat after it.  A phase-locked cortex fires impulses between the beats of its phase grid without shifting it.
+//
+// NOTE: Once the cortex has begun shutting down, impulses are no longer accepted.
 func (ctx *Cortex) Impulse() {
-	ctx.impulse <- nil
+	if !ctx.Alive() {
+		return
+	}
+	ctx.impulse.send(nil, ctx.Overflow.Impulses)
 }
 
 func _hertzToDuration(hz float64) time.Duration {
@@ -138,13 +215,19 @@ func _hertzToDuration(hz float64) time.Duration {
 	return time.Duration(ns)
 }
 
-func (ctx *Cortex) Spark(synapses ...Synapse) {
-	ctx.sanityCheck()
+// Spark begins the cortex's neural activity and wires in any provided synapses.  Sparking an already running cortex
+// simply wires in the additional synapses.
+//
+// NOTE: A cortex cannot be re-sparked after it has shut down - in that case, ErrCortexShutdown is returned.
+func (ctx *Cortex) Spark(synapses ...Synapse) error {
+	if err := ctx.check(); err != nil {
+		return err
+	}
 
 	select {
 	case _, ok := <-ctx.closed:
 		if !ok {
-			panic("cannot re-spark a cortex after shutdown - please create a new cortex")
+			return ErrCortexShutdown
 		}
 	default:
 	}
@@ -152,174 +235,444 @@ func (ctx *Cortex) Spark(synapses ...Synapse) {
 	rec.Verbosef(ctx.Named(), "sparking neural activity\n")
 
 	for _, syn := range synapses {
-		ctx.synapses <- syn
+		ctx.synapses.send(syn, ctx.Overflow.Synapses)
 	}
 
+	ctx.master.Lock()
 	if ctx.running {
-		return
+		ctx.master.Unlock()
+		return nil
 	}
+	ctx.alive.Store(true)
+	ctx.running = true
+	ctx.sparked = true
+	ctx.master.Unlock()
 
-	core.Deferrals() <- func(wg *sync.WaitGroup) {
-		ctx.shutdownWait = wg
-		ctx.Shutdown()
-	}
+	unhook := core.OnShutdown(shutdown.Drain, ctx.Named(), func(wg *sync.WaitGroup) {
+		_ = ctx.Shutdown()
+		<-ctx.decayed
+		wg.Done()
+	})
 
 	go func() {
-		ctx.alive = true
-		ctx.running = true
-
-		defer func() {
-			count := len(ctx.deferrals)
-			if count > 0 {
-				if count > 1 {
-					rec.Verbosef(ctx.Named(), "running %d deferrals\n", count)
-				} else {
-					rec.Verbosef(ctx.Named(), "running %d deferral\n", count)
-				}
-				for len(ctx.deferrals) > 0 {
-					deferral := <-ctx.deferrals
-					if deferral != nil {
-						ctx.deferralWait.Add(1)
-						go func() {
-							defer func() {
-								if r := recover(); r != nil {
-									rec.Printf(ctx.Named(), "deferral error: %v\n", r)
-								}
-							}()
-
-							deferral(ctx.deferralWait)
-						}()
-					}
-				}
-				ctx.deferralWait.Wait()
-			}
-			time.Sleep(time.Second)
-			ctx.hold.Wait()
-			rec.Verbosef(ctx.Named(), "cortex shut down complete\n")
-			ctx.shutdownWait.Done()
-		}()
+		<-ctx.context.Done()
+		if ctx.Alive() {
+			rec.Verbosef(ctx.Named(), "context cancelled\n")
+			_ = ctx.Shutdown()
+		}
+	}()
+
+	go func() {
+		// NOTE: Once decayed, the cortex no longer needs the instance to wait on it
+		defer unhook()
+		defer ctx.decay()
 
 		initial := true
-		last := time.Now()
+		last := ctx.clock.Now()
 		var expected time.Duration
 		var adjustment time.Duration
+		var observed time.Duration
+		var timed bool
 		var frequency float64
+		var target time.Time
+		var fired time.Time
+		var lead time.Duration
+		var engaged bool
+		var lock *PhaseLock
+		var divided bool
 
 	main:
 		for ctx.Alive() {
-			if ctx.Frequency <= 0 {
+			timed = false
+			if ctx.parent != nil {
+				// This is a 'divided' condition - every timed beat is driven by the parent cortex
+				select {
+				case <-ctx.shutdown:
+					break main
+				case <-ctx.impulse.channel:
+					rec.Verbosef(ctx.Named(), "impulsing\n")
+				case rate := <-ctx.divided:
+					// NOTE: A sub-cortex's frequency is only ever written by its own beat loop, never its parent's
+					ctx.Frequency = rate
+					observed = ctx.clock.Now().Sub(last)
+					timed = true
+					divided = true
+				case <-ctx.mute.channel:
+					if !ctx.muted() {
+						break main
+					}
+				case <-ctx.unmute.channel:
+					continue // drain stray unmute signals
+				}
+			} else if ctx.Frequency <= 0 {
 				// This is a 'free-spin' condition
 				select {
-				case <-ctx.mute:
-					rec.Verbosef(ctx.Named(), "muting\n")
-					select {
-					case <-ctx.shutdown:
+				case <-ctx.mute.channel:
+					if !ctx.muted() {
 						break main
-					case <-ctx.impulse:
-						// NOTE: Impulse requests should not break the muted condition
-						ctx.mute <- nil
-					case <-ctx.unmute:
-						rec.Verbosef(ctx.Named(), "unmuting\n")
-						for len(ctx.mute) > 0 {
-							<-ctx.mute
-						}
-						for len(ctx.unmute) > 0 {
-							<-ctx.unmute
-						}
 					}
-				case <-ctx.unmute:
+				case <-ctx.unmute.channel:
 					continue // drain stray unmute signals
 				default:
 				}
 			} else {
 				// This is a 'timer-step' condition
-				expected = last.Add(_hertzToDuration(ctx.Frequency)).Sub(time.Now().Add(adjustment))
 				frequency = ctx.Frequency
+				lock = ctx.PhaseLock
+				if lock != nil {
+					// Phase-locked beats target the next moment on the phase grid, waking early by the learned lead
+					target = ctx.phaseTarget(lock, frequency, ctx.clock.Now(), fired)
+					expected = target.Sub(ctx.clock.Now()) - lead
+				} else {
+					if engaged {
+						engaged = false
+						lead = 0
+						fired = time.Time{}
+						ctx.phaseRelease()
+					}
+					expected = last.Add(_hertzToDuration(frequency)).Sub(ctx.clock.Now().Add(adjustment))
+				}
 				select {
 				case <-ctx.shutdown:
 					break main
-				case <-ctx.impulse:
+				case <-ctx.impulse.channel:
 					rec.Verbosef(ctx.Named(), "impulsing\n")
-				case <-time.After(expected):
-					observed := time.Since(last)
-					adjustment = observed - expected
-
-					// If the frequency changed between cycles, don't try to 'adjust' it =)
-					if ctx.Frequency != frequency {
+				case <-ctx.afterBeat(expected):
+					observed = ctx.clock.Now().Sub(last)
+					timed = true
+
+					if lock != nil {
+						lead = ctx.phaseObserve(lock, frequency, target, ctx.clock.Now(), lead)
+						fired = target
+						engaged = true
 						adjustment = 0
+					} else {
+						adjustment = observed - expected
+
+						// If the frequency changed between cycles, don't try to 'adjust' it =)
+						if ctx.Frequency != frequency {
+							adjustment = 0
+						}
 					}
-				case <-ctx.mute:
-					rec.Verbosef(ctx.Named(), "muting\n")
-					select {
-					case <-ctx.shutdown:
+				case <-ctx.mute.channel:
+					if !ctx.muted() {
 						break main
-					case <-ctx.impulse:
-						rec.Verbosef(ctx.Named(), "impulsing\n")
-						// NOTE: Impulse requests should not break the muted condition
-						ctx.mute <- nil
-					case <-ctx.unmute:
-						rec.Verbosef(ctx.Named(), "unmuting\n")
-						for len(ctx.mute) > 0 {
-							<-ctx.mute
-						}
-						for len(ctx.unmute) > 0 {
-							<-ctx.unmute
-						}
 					}
-				case <-ctx.unmute:
+				case <-ctx.unmute.channel:
 					continue // drain stray unmute signals
 				}
 			}
 
-			for len(ctx.synapses) > 0 {
-				imp := &Impulse{
-					Cortex:   ctx,
-					Timeline: NewTimeline(),
-				}
-				syn := <-ctx.synapses
-				syn(imp)
+			for len(ctx.synapses.channel) > 0 {
+				syn := <-ctx.synapses.channel
+				syn(ctx.spawn())
 			}
 
 			if initial {
 				initial = false
 			} else {
-				ctx.beat++
-				if ctx.BeatPeriod > 0 && ctx.beat > uint(ctx.BeatPeriod) {
-					ctx.beat = 0
+				if beat := ctx.beat.Add(1); ctx.BeatPeriod > 0 && beat > uint64(ctx.BeatPeriod) {
+					ctx.beat.Store(0)
 				}
 			}
 
-			ctx.clock.Broadcast()
-			ctx.addToTimeline(time.Now())
-			last = time.Now()
+			if metrics := ctx.metrics.Load(); metrics != nil {
+				metrics.beat(ctx, observed, timed)
+			}
+
+			ctx.pulses.Add(1)
+			ctx.beatPulse()
+			if divided {
+				// NOTE: The parent engaged this cortex when it divided the beat, allowing it to Settle deterministically
+				divided = false
+				ctx.release()
+			}
+			ctx.divide()
+			ctx.addToTimeline(ctx.clock.Now())
+			last = ctx.clock.Now()
+			if timed {
+				ctx.timeLock.Lock()
+				ctx.timed = last
+				ctx.timeLock.Unlock()
+			}
 		}
 
 		rec.Verbosef(ctx.Named(), "decayed\n")
 
 		// This beat frees the synapses to complete their activation and exit
-		ctx.clock.Broadcast()
+		ctx.beatPulse()
 	}()
+
+	return nil
 }
 
-func (ctx *Cortex) Shutdown(delay ...time.Duration) {
-	ctx.sanityCheck()
+// Shutdown stops the cortex's neural activity after an optional delay, cancelling its context and every impulse
+// context derived from it.  The cortex then decays asynchronously through each shutdown.Phase - see OnShutdown.
+//
+// NOTE: If the cortex has already shut down, ErrCortexShutdown is returned.
+func (ctx *Cortex) Shutdown(delay ...time.Duration) error {
+	if err := ctx.check(); err != nil {
+		return err
+	}
 
-	if !ctx.alive {
-		return
+	if !ctx.Alive() {
+		return ErrCortexShutdown
 	}
 
 	if len(delay) > 0 {
 		rec.Verbosef(ctx.Named(), "cortex shutting down in %v\n", delay[0])
-		time.Sleep(delay[0])
+		ctx.clock.Sleep(delay[0])
 	}
 
 	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	if !ctx.alive.Load() {
+		return ErrCortexShutdown
+	}
+
 	rec.Verbosef(ctx.Named(), "cortex shutting down\n")
 	ctx.running = false
-	ctx.alive = false
+	ctx.timeout = atlas.ShutdownTimeout
+	ctx.deadline = shutdown.Deadline(ctx.timeout)
+	ctx.alive.Store(false)
 	ctx.shutdown <- nil
 	close(ctx.closed)
+	ctx.cancel()
+	return nil
+}
+
+// muted blocks while the cortex is muted, returning false if the cortex was shut down in the meantime.
+//
+// NOTE: Impulse requests should not break the muted condition - they fire a single beat and then the cortex remains muted.
+func (ctx *Cortex) muted() bool {
+	rec.Verbosef(ctx.Named(), "muting\n")
+	for {
+		select {
+		case <-ctx.shutdown:
+			return false
+		case <-ctx.divided:
+			// NOTE: Beats divided down from the parent are discarded while muted
+			ctx.release()
+			continue
+		case <-ctx.impulse.channel:
+			rec.Verbosef(ctx.Named(), "impulsing\n")
+			select {
+			case ctx.mute.channel <- nil:
+			default:
+			}
+		case <-ctx.unmute.channel:
+			rec.Verbosef(ctx.Named(), "unmuting\n")
+			for len(ctx.mute.channel) > 0 {
+				<-ctx.mute.channel
+			}
+			for len(ctx.unmute.channel) > 0 {
+				<-ctx.unmute.channel
+			}
+		}
+		return true
+	}
+}
+
+// OnShutdown registers a named action to run during the provided phase of the cortex's shutdown.  Just like a deferral,
+// the action must call wg.Done() once it has finished - the name is how it's reported should it fail to finish in time.
+//
+// The returned function unregisters the action.
+//
+// See Cortex.Shutdown
+func (ctx *Cortex) OnShutdown(phase shutdown.Phase, named string, action func(wg *sync.WaitGroup)) (remove func()) {
+	ctx.sanityCheck()
+
+	return ctx.hooks.Add(phase, named, action)
+}
+
+// decay walks the cortex through each phase of its shutdown once its neural activity has stopped, then closes decayed.
+//
+//   - Quiesce - the cortex has already stopped accepting synapses and impulses
+//   - Drain - every neuron is given a final beat to finish reacting and decay
+//   - Cleanup - the cortex's deferrals are run
+//   - Exit - the cortex is marked as decayed
+//
+// The entire decay is bounded by a single atlas.ShutdownTimeout from calling Shutdown - any neurons, hooks, or deferrals
+// which fail to finish in time are reported by their Bridge name and abandoned.  Once the deadline has passed, any
+// remaining phases still start their hooks but no longer wait on them.
+func (ctx *Cortex) decay() {
+	onPanic := func(named string, r any) {
+		rec.Errorf(ctx.Named(), "%s error: %v\n", named, r)
+	}
+
+	for _, phase := range shutdown.Phases {
+		hooks := ctx.hooks.Take(phase)
+		switch phase {
+		case shutdown.Drain:
+			hooks = append(hooks, ctx.draining()...)
+		case shutdown.Cleanup:
+			for len(ctx.deferrals) > 0 {
+				if deferral := <-ctx.deferrals; deferral != nil {
+					hooks = append(hooks, shutdown.Hook{Named: "deferral", Action: deferral})
+				}
+			}
+		}
+
+		count := len(hooks)
+		if count == 0 {
+			continue
+		}
+		if count > 1 {
+			rec.Verbosef(ctx.Named(), "running %d %v hooks\n", count, phase)
+		} else {
+			rec.Verbosef(ctx.Named(), "running %d %v hook\n", count, phase)
+		}
+
+		unfinished := shutdown.Await(ctx.deadline, onPanic, hooks...)
+		if len(unfinished) > 0 {
+			rec.Errorf(ctx.Named(), "%v phase timed out after %v waiting on: %s\n", phase, ctx.timeout, strings.Join(unfinished, ", "))
+		}
+	}
+
+	rec.Verbosef(ctx.Named(), "cortex shut down complete\n")
+	close(ctx.decayed)
+}
+
+// draining creates a drain hook for every living neural impulse, each named by its Bridge, which finishes once the impulse decays.
+func (ctx *Cortex) draining() []shutdown.Hook {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	hooks := make([]shutdown.Hook, 0, len(ctx.living))
+	for imp := range ctx.living {
+		hooks = append(hooks, shutdown.Hook{
+			Named: imp.Bridge.String(),
+			Action: func(wg *sync.WaitGroup) {
+				<-imp.decayed
+				wg.Done()
+			},
+		})
+	}
+	return hooks
+}
+
+// track registers a neural impulse as living until it decays.
+func (ctx *Cortex) track(imp *Impulse) {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	ctx.living[imp] = struct{}{}
+}
+
+// untrack removes a decayed neural impulse from the living set.
+func (ctx *Cortex) untrack(imp *Impulse) {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	delete(ctx.living, imp)
+}
+
+// beatPulse wakes every parked neural goroutine to react to the current beat.
+func (ctx *Cortex) beatPulse() {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	ctx.active += ctx.parked
+	ctx.parked = 0
+	ctx.pulse.Broadcast()
+}
+
+// engage registers a neural goroutine which is about to react to the cortex.
+func (ctx *Cortex) engage() {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	ctx.active++
+}
+
+// disengage releases a neural goroutine which has finished reacting to the cortex.
+//
+// NOTE: The master lock must be held by the caller.
+func (ctx *Cortex) disengage() {
+	ctx.active--
+	if ctx.active <= 0 {
+		ctx.active = 0
+		ctx.settled.Broadcast()
+	}
+}
+
+// release is a locking disengage.
+func (ctx *Cortex) release() {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	ctx.disengage()
+}
+
+// await parks the calling neural goroutine until the next beat of the cortex.
+func (ctx *Cortex) await() {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	ctx.parked++
+	ctx.disengage()
+	ctx.pulse.Wait()
+}
+
+// afterBeat waits on the cortex's clock for its next beat - which a VirtualClock tracks as this cortex's beat timer.
+func (ctx *Cortex) afterBeat(d time.Duration) <-chan time.Time {
+	if clock, ok := ctx.clock.(*VirtualClock); ok {
+		return clock.afterBeat(ctx, d)
+	}
+	return ctx.clock.After(d)
+}
+
+// Settle blocks until every neuron wired to this cortex has finished reacting to the current beat - meaning each
+// is either parked awaiting the next beat or has decayed.  Any sub-cortices are then settled in turn.  This is primarily useful when stepping a cortex
+// through a VirtualClock.
+func (ctx *Cortex) Settle() {
+	ctx.sanityCheck()
+
+	ctx.master.Lock()
+	for ctx.active > 0 {
+		ctx.settled.Wait()
+	}
+	children := append([]*Cortex{}, ctx.children...)
 	ctx.master.Unlock()
+
+	for _, child := range children {
+		if child.Alive() {
+			child.Settle()
+		}
+	}
+}
+
+// Beat returns the current beat of the cortex.
+func (ctx *Cortex) Beat() uint {
+	ctx.sanityCheck()
+
+	return uint(ctx.beat.Load())
+}
+
+// Clock returns the Clock this cortex observes.
+func (ctx *Cortex) Clock() Clock {
+	ctx.sanityCheck()
+
+	return ctx.clock
+}
+
+// spawn creates a new Impulse from this cortex, deriving its context from the cortex's own.
+func (ctx *Cortex) spawn(bridge ...string) *Impulse {
+	return ctx.spawnWithin(ctx.context, bridge...)
+}
+
+// spawnWithin creates a new Impulse from this cortex, deriving its context from the provided parent.
+func (ctx *Cortex) spawnWithin(parent context.Context, bridge ...string) *Impulse {
+	imp := &Impulse{
+		Bridge:   bridge,
+		Cortex:   ctx,
+		Timeline: NewTimeline(ctx.clock),
+		traceID:  newTraceID(ctx, id.Next()),
+		decayed:  make(chan any),
+	}
+	imp.context, imp.cancel = context.WithCancel(parent)
+	return imp
 }
 
 func (ctx *Cortex) addToTimeline(moment time.Time) {
@@ -348,19 +701,34 @@ func (ctx *Cortex) Timeline() []time.Time {
 func (ctx *Cortex) Alive() bool {
 	ctx.sanityCheck()
 
-	return ctx.alive
+	return ctx.alive.Load()
 }
 
+// Mute stops the cortex from beating until it's unmuted - along with every sub-cortex beneath it.
 func (ctx *Cortex) Mute() {
 	ctx.sanityCheck()
 
-	ctx.mute <- ctx.Entity
+	ctx.mute.send(ctx.Entity, ctx.Overflow.Mutes)
+	for _, child := range ctx.subCortices() {
+		child.Mute()
+	}
 }
 
+// Unmute resumes the cortex's beating - along with every sub-cortex beneath it.
 func (ctx *Cortex) Unmute() {
 	ctx.sanityCheck()
 
-	ctx.unmute <- ctx.Entity
+	ctx.unmute.send(ctx.Entity, ctx.Overflow.Mutes)
+	for _, child := range ctx.subCortices() {
+		child.Unmute()
+	}
+}
+
+// Context returns the context this cortex lives within.  It is cancelled when the cortex shuts down.
+func (ctx *Cortex) Context() context.Context {
+	ctx.sanityCheck()
+
+	return ctx.context
 }
 
 func (ctx *Cortex) Inception() time.Time {
@@ -369,10 +737,25 @@ func (ctx *Cortex) Inception() time.Time {
 	return ctx.inception
 }
 
+// Dropped returns the number of signals each of the cortex's synaptic channels has dropped (or coalesced) due to overflow.
+func (ctx *Cortex) Dropped() Dropped {
+	ctx.sanityCheck()
+
+	return Dropped{
+		Synapses: ctx.synapses.Dropped(),
+		Impulses: ctx.impulse.Dropped(),
+		Mutes:    ctx.mute.Dropped() + ctx.unmute.Dropped(),
+	}
+}
+
+// Synapses returns the channel synapses can be sent into for wiring into the cortex on its next beat.
+//
+// NOTE: Sending directly into this channel blocks once it's full, regardless of the Overflow policy - use Spark to
+// wire synapses in according to Overflow.Synapses.
 func (ctx *Cortex) Synapses() chan<- Synapse {
 	ctx.sanityCheck()
 
-	return ctx.synapses
+	return ctx.synapses.channel
 }
 
 func (ctx *Cortex) Deferrals() chan<- func(*sync.WaitGroup) {
@@ -382,13 +765,20 @@ func (ctx *Cortex) Deferrals() chan<- func(*sync.WaitGroup) {
 }
 
 func (ctx *Cortex) sanityCheck() {
+	if err := ctx.check(); err != nil {
+		panic(err)
+	}
+}
+
+func (ctx *Cortex) check() error {
 	if !ctx.created {
-		panic("cortices must be created through NewCortex")
+		return ErrCortexUncreated
 	}
 	if ctx.deferrals == nil {
-		panic("deferrals must not be nil")
+		return errors.New("deferrals must not be nil")
 	}
-	if ctx.synapses == nil {
-		panic("synapses must not be nil")
+	if ctx.synapses == nil || ctx.synapses.channel == nil {
+		return errors.New("synapses must not be nil")
 	}
+	return nil
 }
@@ -1,5 +1,13 @@
 package std
 
+import (
+	"context"
+	"sync"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+)
+
 // An Impulse represents the act of a synaptic event between a cortex and neuron.  This contains several key points:
 //
 //   - Bridge is the named synaptic bridge between the cortex and neuron (for tracing purposes)
@@ -7,7 +15,8 @@ package std
 //   - Count indicates the number of activations that this Synapse has processed
 //   - Cortex provides a reference to the cortex that generated this impulse (the neuron can be impulsed by many cortices)
 //   - Neural provides a reference to the neuron that this impulse terminates into.
-//   - Thought holds a reference to the data this synaptic bridge is maturing over time.
+//   - Thought holds a reference to the data this synaptic bridge is maturing over time - see Thinker.
+//   - Context provides a context which is cancelled when the synaptic activity decays or its cortex shuts down.
 type Impulse struct {
 	Bridge     Bridge
 	Timeline   *Timeline
@@ -19,7 +28,84 @@ type Impulse struct {
 	Decay      bool
 	Mute       bool
 
-	Thought *Thought
+	Thought Thinker
 
 	currentEvent *SynapticEvent
+	lifecycle    life.Cycle
+	traceID      [16]byte
+	supervised   *supervised
+
+	context  context.Context
+	cancel   context.CancelFunc
+	decayed  chan any // NOTE: decayed is closed once the neural activity has ended
+	decaying sync.Once
+}
+
+// Context returns the context this impulse was derived under.  It's cancelled once the synaptic activity decays or
+// the originating cortex shuts down, allowing neurons which perform blocking I/O to stop together with their cortex.
+func (imp *Impulse) Context() context.Context {
+	if imp.context == nil {
+		return context.Background()
+	}
+	return imp.context
+}
+
+// Clock returns the Clock of the cortex which fired this impulse - or the SystemClock, if there isn't one.
+func (imp *Impulse) Clock() Clock {
+	if imp == nil || imp.Cortex == nil || imp.Cortex.clock == nil {
+		return SystemClock
+	}
+	return imp.Cortex.clock
+}
+
+// activate stamps the synaptic event with its activation moment and the impulse's current state, then records it on the timeline.
+func (imp *Impulse) activate(event *SynapticEvent, moment time.Time) {
+	event.Activation = moment
+	event.beat = imp.Beat
+	event.beatPeriod = imp.BeatPeriod
+	event.count = imp.Count
+	imp.Timeline.Add(*event)
+}
+
+// complete stamps the synaptic event with its completion moment, then traces and measures it if the cortex is doing so.
+func (imp *Impulse) complete(event *SynapticEvent, moment time.Time) {
+	imp.Timeline.setCompleted(event.id, moment)
+	if imp.Cortex != nil {
+		imp.Cortex.trace(imp, event, moment)
+		if metrics := imp.Cortex.metrics.Load(); metrics != nil {
+			metrics.completion(imp)
+		}
+	}
+}
+
+// alive reports whether the impulse should keep reacting - meaning its cortex is alive and its context hasn't been
+// cancelled (for instance, by a Supervisor stopping it).
+func (imp *Impulse) alive() bool {
+	return (*imp.Cortex).Alive() && imp.Context().Err() == nil
+}
+
+// fail reports a neural panic to the impulse's Supervisor, if it has one - stopping the impulse from reacting any further.
+func (imp *Impulse) fail(reason any) {
+	if imp.supervised != nil {
+		imp.cancel()
+		imp.supervised.fail(reason)
+	}
+}
+
+// decay cancels the impulse's context and notifies its cortex and Supervisor, if it has one, that the neural activity has ended.
+func (imp *Impulse) decay() {
+	if imp.cancel != nil {
+		imp.cancel()
+	}
+	imp.decaying.Do(func() {
+		if imp.decayed != nil {
+			close(imp.decayed)
+		}
+		if imp.Cortex != nil {
+			imp.Cortex.untrack(imp)
+		}
+	})
+	if imp.supervised != nil {
+		imp.supervised.exited()
+	}
 }
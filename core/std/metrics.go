@@ -0,0 +1,197 @@
+package std
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Metrics is a registry which aggregates the temporal behavior of every cortex measured into it, as well as each
+// neuron those cortices fire.  Measurement is opt-in, per cortex:
+//
+//	metrics := std.NewMetrics()
+//	cortex.Measure(metrics)
+//
+// See neural.Net.Metrics for serving a registry in the Prometheus text exposition format.
+type Metrics struct {
+	cortices map[uint64]*cortexMeasurement
+	gate     sync.Mutex
+}
+
+// CortexMetrics is a snapshot of a single cortex's measurements.
+type CortexMetrics struct {
+	Cortex    string
+	Frequency float64
+
+	// Beats is the number of beats the cortex has fired since it was measured.
+	Beats uint64
+
+	// BeatPeriod is the most recently observed duration between timed beats.
+	BeatPeriod time.Duration
+
+	// BeatDrift is the most recently observed difference between the BeatPeriod and the period of the cortex's Frequency.
+	BeatDrift time.Duration
+
+	// BeatDriftMax is the largest absolute BeatDrift observed.
+	BeatDriftMax time.Duration
+
+	// SynapseQueue is the number of synapses waiting to be wired into the cortex.
+	SynapseQueue int
+
+	// ImpulseQueue is the number of impulse requests waiting to be processed by the cortex.
+	ImpulseQueue int
+
+	// Dropped holds the number of signals each synaptic channel has dropped due to overflow.
+	Dropped Dropped
+
+	Neurons []NeuronMetrics
+}
+
+// NeuronMetrics is a snapshot of a single neuron's measurements, as observed through its impulse's Timeline.
+type NeuronMetrics struct {
+	Neuron string
+	Bridge string
+
+	// Activations is the number of activations the neuron has completed, including those which panicked.
+	Activations uint64
+
+	// Panics is the number of activations which panicked - a subset of Activations.
+	Panics uint64
+
+	ResponseTime     time.Duration
+	RunTime          time.Duration
+	RunTimeTotal     time.Duration
+	CyclePeriod      time.Duration
+	RefractoryPeriod time.Duration
+}
+
+type cortexMeasurement struct {
+	cortex *Cortex
+	CortexMetrics
+	neurons map[string]*NeuronMetrics
+}
+
+// NewMetrics creates a new empty metrics registry.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		cortices: make(map[uint64]*cortexMeasurement),
+	}
+}
+
+// Snapshot returns a copy of every measurement in the registry, ordered by cortex and then neural bridge.
+func (m *Metrics) Snapshot() []CortexMetrics {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	out := make([]CortexMetrics, 0, len(m.cortices))
+	for _, c := range m.cortices {
+		snapshot := c.CortexMetrics
+		snapshot.SynapseQueue = len(c.cortex.synapses.channel)
+		snapshot.ImpulseQueue = len(c.cortex.impulse.channel)
+		snapshot.Dropped = c.cortex.Dropped()
+		snapshot.Neurons = make([]NeuronMetrics, 0, len(c.neurons))
+		for _, n := range c.neurons {
+			snapshot.Neurons = append(snapshot.Neurons, *n)
+		}
+		sort.Slice(snapshot.Neurons, func(i, j int) bool {
+			return snapshot.Neurons[i].Bridge < snapshot.Neurons[j].Bridge
+		})
+		out = append(out, snapshot)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Cortex < out[j].Cortex
+	})
+	return out
+}
+
+func (m *Metrics) register(ctx *Cortex) {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	if _, ok := m.cortices[ctx.GetID()]; ok {
+		return
+	}
+	m.cortices[ctx.GetID()] = &cortexMeasurement{
+		cortex:        ctx,
+		CortexMetrics: CortexMetrics{Cortex: ctx.Named(), Frequency: ctx.Frequency},
+		neurons:       make(map[string]*NeuronMetrics),
+	}
+}
+
+func (m *Metrics) beat(ctx *Cortex, period time.Duration, timed bool) {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	c, ok := m.cortices[ctx.GetID()]
+	if !ok {
+		return
+	}
+	c.Beats++
+	c.Frequency = ctx.Frequency
+	if !timed || ctx.Frequency <= 0 {
+		return
+	}
+
+	c.BeatPeriod = period
+	c.BeatDrift = period - _hertzToDuration(ctx.Frequency)
+	drift := c.BeatDrift
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > c.BeatDriftMax {
+		c.BeatDriftMax = drift
+	}
+}
+
+func (m *Metrics) neuron(imp *Impulse) *NeuronMetrics {
+	c, ok := m.cortices[imp.Cortex.GetID()]
+	if !ok {
+		return nil
+	}
+
+	bridge := imp.Bridge.String()
+	n, ok := c.neurons[bridge]
+	if !ok {
+		n = &NeuronMetrics{Bridge: bridge}
+		if len(imp.Bridge) > 0 {
+			n.Neuron = imp.Bridge[len(imp.Bridge)-1]
+		}
+		c.neurons[bridge] = n
+	}
+	return n
+}
+
+func (m *Metrics) completion(imp *Impulse) {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	n := m.neuron(imp)
+	if n == nil {
+		return
+	}
+	n.Activations++
+	n.ResponseTime = imp.Timeline.ResponseTime()
+	n.RunTime = imp.Timeline.RunTime()
+	n.RunTimeTotal += n.RunTime
+	n.CyclePeriod = imp.Timeline.CyclePeriod()
+	n.RefractoryPeriod = imp.Timeline.RefractoryPeriod()
+}
+
+func (m *Metrics) panicked(imp *Impulse) {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	if n := m.neuron(imp); n != nil {
+		n.Panics++
+	}
+}
+
+// Measure opts the cortex into the provided metrics registry.  Providing nil stops measuring the cortex.
+func (ctx *Cortex) Measure(metrics *Metrics) {
+	ctx.sanityCheck()
+
+	if metrics != nil {
+		metrics.register(ctx)
+	}
+	ctx.metrics.Store(metrics)
+}
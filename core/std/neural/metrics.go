@@ -0,0 +1,129 @@
+package neural
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// Metrics serves the provided registry at '/metrics' in the Prometheus text exposition format.  Every cortex you'd like
+// to observe must first be measured into the registry:
+//
+//	metrics := std.NewMetrics()
+//	cortex.Measure(metrics)
+//	cortex.Synapses() <- neural.Net.Metrics(life.Looping, "metrics", ":9090", metrics)
+func (_net) Metrics(lifecycle life.Cycle, named string, address string, metrics *std.Metrics, onDisconnect ...func(*std.Impulse)) std.Synapse {
+	if metrics == nil {
+		panic("metrics registry must not be nil")
+	}
+
+	return Net.Server(lifecycle, named, address, func(imp *std.Impulse) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			_ = expose(w, metrics.Snapshot())
+		})
+		return mux
+	}, onDisconnect...)
+}
+
+// expose writes the snapshot in the Prometheus text exposition format.
+func expose(w io.Writer, snapshot []std.CortexMetrics) error {
+	out := bufio.NewWriter(w)
+
+	family := func(name string, kind string, help string, samples func(emit func(labels string, value float64))) {
+		_, _ = fmt.Fprintf(out, "# HELP %s %s\n", name, help)
+		_, _ = fmt.Fprintf(out, "# TYPE %s %s\n", name, kind)
+		samples(func(labels string, value float64) {
+			_, _ = fmt.Fprintf(out, "%s{%s} %g\n", name, labels, value)
+		})
+	}
+
+	cortexFamily := func(name string, kind string, help string, value func(std.CortexMetrics) float64) {
+		family(name, kind, help, func(emit func(string, float64)) {
+			for _, c := range snapshot {
+				emit(labels("cortex", c.Cortex), value(c))
+			}
+		})
+	}
+
+	neuronFamily := func(name string, kind string, help string, value func(std.NeuronMetrics) float64) {
+		family(name, kind, help, func(emit func(string, float64)) {
+			for _, c := range snapshot {
+				for _, n := range c.Neurons {
+					emit(labels("cortex", c.Cortex, "neuron", n.Neuron, "bridge", n.Bridge), value(n))
+				}
+			}
+		})
+	}
+
+	cortexFamily("janos_cortex_beats_total", "counter", "Beats fired by the cortex.", func(c std.CortexMetrics) float64 {
+		return float64(c.Beats)
+	})
+	cortexFamily("janos_cortex_frequency_hertz", "gauge", "Configured frequency of the cortex.", func(c std.CortexMetrics) float64 {
+		return c.Frequency
+	})
+	cortexFamily("janos_cortex_beat_period_seconds", "gauge", "Most recently observed duration between timed beats.", func(c std.CortexMetrics) float64 {
+		return c.BeatPeriod.Seconds()
+	})
+	cortexFamily("janos_cortex_beat_drift_seconds", "gauge", "Most recently observed beat period minus the period of the configured frequency.", func(c std.CortexMetrics) float64 {
+		return c.BeatDrift.Seconds()
+	})
+	cortexFamily("janos_cortex_beat_drift_max_seconds", "gauge", "Largest absolute beat drift observed.", func(c std.CortexMetrics) float64 {
+		return c.BeatDriftMax.Seconds()
+	})
+	cortexFamily("janos_cortex_synapse_queue_depth", "gauge", "Synapses waiting to be wired into the cortex.", func(c std.CortexMetrics) float64 {
+		return float64(c.SynapseQueue)
+	})
+	cortexFamily("janos_cortex_impulse_queue_depth", "gauge", "Impulse requests waiting to be processed by the cortex.", func(c std.CortexMetrics) float64 {
+		return float64(c.ImpulseQueue)
+	})
+
+	family("janos_cortex_dropped_signals_total", "counter", "Signals dropped or coalesced due to synaptic channel overflow.", func(emit func(string, float64)) {
+		for _, c := range snapshot {
+			emit(labels("cortex", c.Cortex, "channel", "synapses"), float64(c.Dropped.Synapses))
+			emit(labels("cortex", c.Cortex, "channel", "impulses"), float64(c.Dropped.Impulses))
+			emit(labels("cortex", c.Cortex, "channel", "mutes"), float64(c.Dropped.Mutes))
+		}
+	})
+
+	neuronFamily("janos_neuron_activations_total", "counter", "Completed neural activations, including those which panicked.", func(n std.NeuronMetrics) float64 {
+		return float64(n.Activations)
+	})
+	neuronFamily("janos_neuron_panics_total", "counter", "Neural activations which panicked - a subset of the completed activations.", func(n std.NeuronMetrics) float64 {
+		return float64(n.Panics)
+	})
+	neuronFamily("janos_neuron_response_time_seconds", "gauge", "Most recent duration between inception and activation.", func(n std.NeuronMetrics) float64 {
+		return n.ResponseTime.Seconds()
+	})
+	neuronFamily("janos_neuron_run_time_seconds", "gauge", "Most recent duration between activation and completion.", func(n std.NeuronMetrics) float64 {
+		return n.RunTime.Seconds()
+	})
+	neuronFamily("janos_neuron_run_time_seconds_total", "counter", "Total duration spent between activation and completion.", func(n std.NeuronMetrics) float64 {
+		return n.RunTimeTotal.Seconds()
+	})
+	neuronFamily("janos_neuron_cycle_period_seconds", "gauge", "Most recent duration between activations.", func(n std.NeuronMetrics) float64 {
+		return n.CyclePeriod.Seconds()
+	})
+	neuronFamily("janos_neuron_refractory_period_seconds", "gauge", "Most recent duration between the last completion and this activation.", func(n std.NeuronMetrics) float64 {
+		return n.RefractoryPeriod.Seconds()
+	})
+
+	return out.Flush()
+}
+
+// labels formats the provided key/value pairs as Prometheus labels, escaping each value.
+func labels(pairs ...string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	out := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		out = append(out, fmt.Sprintf(`%s="%s"`, pairs[i], escaper.Replace(pairs[i+1])))
+	}
+	return strings.Join(out, ",")
+}
@@ -0,0 +1,433 @@
+package neural
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/id"
+	"git.ignitelabs.net/janos/core/sys/rec"
+)
+
+// A Middleware wraps an HTTP handler with additional behavior.  It's handed the impulse of the neural server it's
+// serving under, so it may log against the server's bridge or stop together with its cortex.  See HTTP.Chain
+type Middleware func(imp *std.Impulse, next http.Handler) http.Handler
+
+type _http byte
+
+// HTTP provides the middleware which can be chained around a neural server's handler.
+var HTTP _http
+
+// Chain wraps the handler function with the provided middleware, ready to be handed to Net.Server.  The first
+// middleware is the outermost, seeing every request first:
+//
+//	neural.Net.Server(life.Looping, "site", ":4242", neural.HTTP.Chain(Handler,
+//		neural.HTTP.RequestID(),
+//		neural.HTTP.Logging(),
+//		neural.HTTP.Recovery(),
+//		neural.HTTP.Gzip(),
+//	))
+func (_http) Chain(handlerFn func(imp *std.Impulse) http.Handler, middleware ...Middleware) func(imp *std.Impulse) http.Handler {
+	if handlerFn == nil {
+		panic(errors.New("handler function is nil"))
+	}
+
+	return func(imp *std.Impulse) http.Handler {
+		handler := handlerFn(imp)
+		for i := len(middleware) - 1; i >= 0; i-- {
+			handler = middleware[i](imp, handler)
+		}
+		return handler
+	}
+}
+
+type requestKey struct{}
+
+// RequestIDHeader is the header a request's identifier is echoed back on.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID assigns every request a unique identifier from id.Next, echoing it back on the RequestIDHeader.  The
+// identifier can be retrieved by any later handler through RequestIDOf, and is included in the Logging output.
+func (_http) RequestID() Middleware {
+	return func(imp *std.Impulse, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := id.Next()
+			w.Header().Set(RequestIDHeader, strconv.FormatUint(requestID, 10))
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestKey{}, requestID)))
+		})
+	}
+}
+
+// RequestIDOf returns the identifier assigned to the request by the RequestID middleware - or false, if none was.
+func RequestIDOf(r *http.Request) (uint64, bool) {
+	requestID, ok := r.Context().Value(requestKey{}).(uint64)
+	return requestID, ok
+}
+
+// Logging records every request through rec against the server's bridge - including its status, size and duration.
+func (_http) Logging() Middleware {
+	return func(imp *std.Impulse, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorded := &recorder{ResponseWriter: w}
+			next.ServeHTTP(recorded, r)
+
+			prefix := ""
+			if requestID, ok := RequestIDOf(r); ok {
+				prefix = fmt.Sprintf("[%d] ", requestID)
+			}
+			rec.Printf(imp.Bridge.String(), "%s%s %s%s → %d (%d bytes in %v)\n", prefix, r.Method, r.Host, r.URL.RequestURI(), recorded.Status(), recorded.size, time.Since(start))
+		})
+	}
+}
+
+// Recovery recovers any panic raised while serving a request, recording it through rec and - if nothing has been
+// written yet - responding with a 500.
+//
+// NOTE: http.ErrAbortHandler is re-raised, as it's the standard means of deliberately aborting a response.
+func (_http) Recovery() Middleware {
+	return func(imp *std.Impulse, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorded := &recorder{ResponseWriter: w}
+			defer func() {
+				if err := recover(); err != nil {
+					if err == http.ErrAbortHandler {
+						panic(err)
+					}
+					rec.Errorf(imp.Bridge.String(), "recovered from panic serving %s %s: %v\n", r.Method, r.URL.RequestURI(), err)
+					if recorded.status == 0 {
+						http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					}
+				}
+			}()
+			next.ServeHTTP(recorded, r)
+		})
+	}
+}
+
+// Gzip compresses responses for every client which accepts it.  Responses which already carry a Content-Encoding, or
+// which have no body, are passed through untouched.
+func (_http) Gzip(level ...int) Middleware {
+	l := gzip.DefaultCompression
+	if len(level) > 0 {
+		l = level[0]
+	}
+	if _, err := gzip.NewWriterLevel(io.Discard, l); err != nil {
+		panic(err)
+	}
+
+	pool := sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, l)
+		return w
+	}}
+
+	return func(imp *std.Impulse, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !accepts(r, "gzip") || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			compressed := &compressor{ResponseWriter: w, pool: &pool}
+			defer compressed.close()
+			next.ServeHTTP(compressed, r)
+		})
+	}
+}
+
+// accepts reports whether the request's Accept-Encoding includes the provided encoding.
+func accepts(r *http.Request, encoding string) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(accepted), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// CORS configures the Cross-Origin Resource Sharing middleware.
+type CORS struct {
+	// Origins lists the origins allowed to make requests - a single "*" allows any origin.
+	Origins []string
+
+	// Methods lists the methods allowed in a preflight request - defaulting to GET, HEAD and POST.
+	Methods []string
+
+	// Headers lists the request headers allowed in a preflight request.
+	Headers []string
+
+	// Expose lists the response headers a browser may expose to the calling script.
+	Expose []string
+
+	// Credentials allows cookies and authorization headers to accompany requests.
+	Credentials bool
+
+	// MaxAge is how long a browser may cache a preflight response.  Zero leaves it to the browser.
+	MaxAge time.Duration
+}
+
+// CORS answers cross-origin requests from the configured origins - responding to preflight requests directly, and
+// decorating every other request before handing it on.  Requests from other origins are handed on undecorated, leaving
+// the browser to refuse them.
+func (_http) CORS(cors CORS) Middleware {
+	if len(cors.Methods) == 0 {
+		cors.Methods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+	anyOrigin := len(cors.Origins) == 1 && cors.Origins[0] == "*"
+	origins := make(map[string]struct{}, len(cors.Origins))
+	for _, origin := range cors.Origins {
+		origins[strings.ToLower(origin)] = struct{}{}
+	}
+
+	return func(imp *std.Impulse, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			header := w.Header()
+			header.Add("Vary", "Origin")
+
+			if _, ok := origins[strings.ToLower(origin)]; origin == "" || (!anyOrigin && !ok) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// NOTE: Credentials can't accompany a wildcard origin, so the origin is echoed back instead
+			if anyOrigin && !cors.Credentials {
+				header.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				header.Set("Access-Control-Allow-Origin", origin)
+			}
+			if cors.Credentials {
+				header.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				if len(cors.Expose) > 0 {
+					header.Set("Access-Control-Expose-Headers", strings.Join(cors.Expose, ", "))
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+			header.Set("Access-Control-Allow-Methods", strings.Join(cors.Methods, ", "))
+			if len(cors.Headers) > 0 {
+				header.Set("Access-Control-Allow-Headers", strings.Join(cors.Headers, ", "))
+			}
+			if cors.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", strconv.Itoa(int(cors.MaxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// RateLimit limits each client to the provided number of requests per second, allowing short bursts of up to the
+// provided size.  Requests beyond the limit are refused with a 429 and a Retry-After header.
+//
+// Clients are identified by their remote IP address, unless a key function is provided - for instance, to identify
+// clients by a header set by a trusted proxy.
+func (_http) RateLimit(perSecond float64, burst int, key ...func(*http.Request) string) Middleware {
+	if perSecond <= 0 || burst <= 0 {
+		panic(errors.New("rate limits must be positive"))
+	}
+
+	keyFn := remoteIP
+	if len(key) > 0 && key[0] != nil {
+		keyFn = key[0]
+	}
+
+	type bucket struct {
+		tokens float64
+		last   time.Time
+	}
+	var gate sync.Mutex
+	buckets := make(map[string]*bucket)
+	var swept time.Time
+	// NOTE: A bucket which has been idle long enough to refill is indistinguishable from a new one
+	idle := time.Duration(float64(burst) / perSecond * float64(time.Second))
+
+	allow := func(client string, now time.Time) (bool, time.Duration) {
+		gate.Lock()
+		defer gate.Unlock()
+
+		if now.Sub(swept) > idle {
+			for k, b := range buckets {
+				if now.Sub(b.last) > idle {
+					delete(buckets, k)
+				}
+			}
+			swept = now
+		}
+
+		b, ok := buckets[client]
+		if !ok {
+			b = &bucket{tokens: float64(burst), last: now}
+			buckets[client] = b
+		}
+		b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+		b.last = now
+
+		if b.tokens < 1 {
+			return false, time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+		}
+		b.tokens--
+		return true, 0
+	}
+
+	return func(imp *std.Impulse, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, wait := allow(keyFn(r), time.Now()); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// remoteIP returns the IP address portion of the request's remote address.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// recorder observes the status and size of a response as it's written.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+// Status returns the response status - which is implicitly 200 once anything has been written.
+func (r *recorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(data)
+	r.size += int64(n)
+	return n, err
+}
+
+// Flush sends any buffered data to the client.
+func (r *recorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Hijack hands over the underlying connection - used when upgrading to a websocket.
+func (r *recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// compressor gzips a response - deciding whether to compress once the headers are written.
+type compressor struct {
+	http.ResponseWriter
+	pool    *sync.Pool
+	gz      *gzip.Writer
+	decided bool
+}
+
+func (c *compressor) WriteHeader(status int) {
+	if c.decided {
+		return
+	}
+	c.decided = true
+
+	header := c.Header()
+	bodiless := status < 200 || status == http.StatusNoContent || status == http.StatusNotModified
+
+	// NOTE: A partial response's Content-Range describes the identity encoding, so compressing it would corrupt the range
+	partial := status == http.StatusPartialContent || header.Get("Content-Range") != ""
+	if !bodiless && !partial && header.Get("Content-Encoding") == "" {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		c.gz = c.pool.Get().(*gzip.Writer)
+		c.gz.Reset(c.ResponseWriter)
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *compressor) Write(data []byte) (int, error) {
+	if !c.decided {
+		if c.Header().Get("Content-Type") == "" {
+			c.Header().Set("Content-Type", http.DetectContentType(data))
+		}
+		c.WriteHeader(http.StatusOK)
+	}
+	if c.gz == nil {
+		return c.ResponseWriter.Write(data)
+	}
+	return c.gz.Write(data)
+}
+
+// Flush sends any compressed data buffered so far to the client.
+func (c *compressor) Flush() {
+	if c.gz != nil {
+		_ = c.gz.Flush()
+	}
+	_ = http.NewResponseController(c.ResponseWriter).Flush()
+}
+
+// Hijack hands over the underlying connection - used when upgrading to a websocket, which is never compressed.
+func (c *compressor) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	c.decided = true
+	return http.NewResponseController(c.ResponseWriter).Hijack()
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer.
+func (c *compressor) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+func (c *compressor) close() {
+	if c.gz != nil {
+		_ = c.gz.Close()
+		c.pool.Put(c.gz)
+		c.gz = nil
+	}
+}
@@ -0,0 +1,29 @@
+package neural
+
+import (
+	"net/http"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/goproxy"
+)
+
+// ModuleProxy serves Go modules over the GOPROXY protocol from the provided source - see goproxy.Source.  For instance,
+// to serve a directory of git repositories under git.ignitelabs.net, caching every version fetched:
+//
+//	source := goproxy.Cached(goproxy.Git("/srv/git", "git.ignitelabs.net"), "/var/cache/goproxy")
+//	cortex.Synapses() <- neural.Net.ModuleProxy(life.Looping, "proxy", ":4243", source)
+//
+// The go command can then fetch from it directly:
+//
+//	GOPROXY=http://localhost:4243 GONOSUMDB=git.ignitelabs.net go mod download git.ignitelabs.net/janos/core@latest
+func (_net) ModuleProxy(lifecycle life.Cycle, named string, address string, source goproxy.Source, onDisconnect ...func(*std.Impulse)) std.Synapse {
+	if source == nil {
+		panic("module source must not be nil")
+	}
+
+	handler := goproxy.Handler(source)
+	return Net.Server(lifecycle, named, address, func(imp *std.Impulse) http.Handler {
+		return handler
+	}, onDisconnect...)
+}
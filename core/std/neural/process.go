@@ -8,11 +8,14 @@ import (
 	"os/signal"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"git.ignitelabs.net/janos/core/enum/life"
 	"git.ignitelabs.net/janos/core/std"
 	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/watch"
 )
 
 // SubProcess sparks off a separate process of the provided command as a neural child of the current instance.  This means
@@ -22,7 +25,25 @@ func (_shell) SubProcess(lifecycle life.Cycle, named string, command []string, o
 	return Shell.SubProcessAt(lifecycle, named, command, dir, onExit...)
 }
 
+// SubProcessAt sparks off a separate process of the provided command, run from the provided working directory.  See SubProcess
 func (_shell) SubProcessAt(lifecycle life.Cycle, named string, command []string, path string, onExit ...func(*std.Impulse)) std.Synapse {
+	return subProcess(lifecycle, named, command, path, nil, onExit...)
+}
+
+// SubProcessWatched sparks off a separate process of the provided command, run from the provided working directory, and
+// watches the directory tree for changes.  When anything within it changes, the running process is stopped - allowing a
+// looping neuron to immediately restart it against the changed files.  If no debounce is provided, changes are
+// collected for 250ms before the restart, so a burst of saves causes only a single restart.
+//
+// NOTE: Stopping the process calls onExit, just as if it had exited on its own.
+func (_shell) SubProcessWatched(lifecycle life.Cycle, named string, command []string, path string, debounce time.Duration, onExit ...func(*std.Impulse)) std.Synapse {
+	if debounce <= 0 {
+		debounce = 250 * time.Millisecond
+	}
+	return subProcess(lifecycle, named, command, path, &watch.Options{Recursive: true, Debounce: debounce}, onExit...)
+}
+
+func subProcess(lifecycle life.Cycle, named string, command []string, path string, watching *watch.Options, onExit ...func(*std.Impulse)) std.Synapse {
 	if len(command) == 0 {
 		panic("no command provided")
 	}
@@ -34,10 +55,32 @@ func (_shell) SubProcessAt(lifecycle life.Cycle, named string, command []string,
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	var gate sync.Mutex
+	var watcher *watch.Watcher
+	restart := func() {}
+
 	return std.NewSynapse(lifecycle, named, func(imp *std.Impulse) {
+		gate.Lock()
+		if watching != nil && watcher == nil {
+			w, err := watch.OnChange(path, func(e watch.Event) {
+				gate.Lock()
+				defer gate.Unlock()
+				rec.Verbosef(imp.Bridge.String(), "%v %v - restarting sub-process\n", e.Path, e.Change)
+				restart()
+			}, *watching)
+			if err != nil {
+				rec.Errorf(imp.Bridge.String(), "failed to watch '%v': %v\n", path, err)
+			}
+			watcher = w
+		}
+		run, stop := context.WithCancel(ctx)
+		restart = stop
+		gate.Unlock()
+		defer stop()
+
 		rec.Printf(imp.Bridge.String(), "sparking sub-process '%v'\n", command[0])
 
-		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd := exec.CommandContext(run, command[0], command[1:]...)
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -80,6 +123,13 @@ func (_shell) SubProcessAt(lifecycle life.Cycle, named string, command []string,
 		}
 		return false
 	}, func(imp *std.Impulse) {
+		gate.Lock()
+		defer gate.Unlock()
+
+		if watcher != nil {
+			_ = watcher.Close()
+			watcher = nil
+		}
 		cancel()
 		ctx, cancel = context.WithCancel(context.Background())
 	})
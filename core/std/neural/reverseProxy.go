@@ -0,0 +1,402 @@
+package neural
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/balance"
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/rec"
+)
+
+// ProxyOptions configures how a pool of Upstreams is balanced and kept healthy.
+type ProxyOptions struct {
+	// Strategy chooses the upstream serving each request - see balance.Strategy
+	Strategy balance.Strategy
+
+	// HashKey provides the key a ConsistentHash strategy balances by - defaulting to the client's IP address.
+	HashKey func(*http.Request) string
+
+	// PreserveHost forwards the request's original Host header, rather than the upstream's.
+	PreserveHost bool
+
+	// HealthPath is the path the HealthCheck neuron requests from every upstream - defaulting to "/".  Any response
+	// below 500 is considered healthy.
+	HealthPath string
+
+	// HealthTimeout bounds each active health check - defaulting to 2 seconds.
+	HealthTimeout time.Duration
+
+	// FailureThreshold is how many consecutive failures eject an upstream from the pool - defaulting to 3.  A
+	// failure is any error reaching the upstream, or any 5xx response.
+	FailureThreshold int
+
+	// Ejection is how long an ejected upstream is left out of the pool - defaulting to 10 seconds.
+	Ejection time.Duration
+
+	// Retries is how many other upstreams a failed request is retried against - defaulting to 2.  Only requests
+	// which can be replayed are retried, and only when the upstream couldn't be reached - once an upstream responds,
+	// its response is final.  Set this negative to disable retries.
+	Retries int
+}
+
+// upstream is a single member of the pool.
+type upstream struct {
+	target    *url.URL
+	proxy     *httputil.ReverseProxy
+	active    atomic.Int64
+	unhealthy atomic.Bool
+	failures  atomic.Int32
+	ejected   atomic.Int64 // NOTE: ejected holds the unix nanosecond the ejection ends
+}
+
+// available reports whether the upstream may currently be chosen.
+func (u *upstream) available(now time.Time) bool {
+	return !u.unhealthy.Load() && now.UnixNano() >= u.ejected.Load()
+}
+
+// An UpstreamStatus describes the current state of a single upstream.
+type UpstreamStatus struct {
+	Target  string
+	Healthy bool
+	Ejected bool
+	Active  int64
+}
+
+// Upstreams is a pool of HTTP servers which requests are balanced across.  It's an http.Handler in its own right,
+// but is typically served through Net.ReverseProxy and kept healthy through Net.HealthCheck.
+type Upstreams struct {
+	ProxyOptions
+	name      string
+	upstreams []*upstream
+	ring      []ringPoint
+	next      atomic.Uint64
+	transport *http.Transport
+}
+
+// ringPoint places an upstream on the consistent hash ring.
+type ringPoint struct {
+	hash     uint64
+	upstream *upstream
+}
+
+// replicas is how many points each upstream occupies on the consistent hash ring - spreading keys evenly.
+const replicas = 64
+
+// NewUpstreams creates a pool balancing across the provided targets, such as "http://localhost:8081".
+func NewUpstreams(named string, options ProxyOptions, targets ...string) *Upstreams {
+	if len(targets) == 0 {
+		panic(errors.New("at least one upstream is required"))
+	}
+	if options.HashKey == nil {
+		options.HashKey = remoteIP
+	}
+	if options.HealthPath == "" {
+		options.HealthPath = "/"
+	}
+	if options.HealthTimeout <= 0 {
+		options.HealthTimeout = 2 * time.Second
+	}
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = 3
+	}
+	if options.Ejection <= 0 {
+		options.Ejection = 10 * time.Second
+	}
+	if options.Retries == 0 {
+		options.Retries = 2
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	pool := &Upstreams{ProxyOptions: options, name: named, transport: transport}
+	for _, target := range targets {
+		parsed, err := url.Parse(target)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			panic(errors.New("invalid upstream '" + target + "'"))
+		}
+
+		u := &upstream{target: parsed}
+		u.proxy = &httputil.ReverseProxy{
+			Transport: transport,
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.SetURL(parsed)
+				r.SetXForwarded()
+				if options.PreserveHost {
+					r.Out.Host = r.In.Host
+				}
+			},
+			ModifyResponse: func(response *http.Response) error {
+				if response.StatusCode >= 500 {
+					pool.failed(u, errors.New(response.Status))
+				} else {
+					pool.succeeded(u)
+				}
+				return nil
+			},
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				// NOTE: The error is recorded rather than written, so the request can be retried elsewhere
+				if a, ok := r.Context().Value(attemptKey{}).(*attempt); ok {
+					a.err = err
+				}
+			},
+		}
+		pool.upstreams = append(pool.upstreams, u)
+
+		for i := 0; i < replicas; i++ {
+			pool.ring = append(pool.ring, ringPoint{hash: hash(target + "#" + strconv.Itoa(i)), upstream: u})
+		}
+	}
+	slices.SortFunc(pool.ring, func(a, b ringPoint) int {
+		switch {
+		case a.hash < b.hash:
+			return -1
+		case a.hash > b.hash:
+			return 1
+		}
+		return 0
+	})
+	return pool
+}
+
+// hash places a key on the consistent hash ring.
+//
+// NOTE: FNV barely touches its high bits when only the final byte differs - as with "client-1" and "client-2" - so the
+// result is mixed through the splitmix64 finalizer to spread similar keys around the ring
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, key)
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Status describes every upstream in the pool.
+func (p *Upstreams) Status() []UpstreamStatus {
+	now := time.Now()
+	out := make([]UpstreamStatus, len(p.upstreams))
+	for i, u := range p.upstreams {
+		out[i] = UpstreamStatus{
+			Target:  u.target.String(),
+			Healthy: !u.unhealthy.Load(),
+			Ejected: now.UnixNano() < u.ejected.Load(),
+			Active:  u.active.Load(),
+		}
+	}
+	return out
+}
+
+// pick chooses an available upstream which hasn't already been tried - or nil, if none remain.
+func (p *Upstreams) pick(r *http.Request, tried []*upstream) *upstream {
+	now := time.Now()
+	candidate := func(u *upstream) bool {
+		return u.available(now) && !slices.Contains(tried, u)
+	}
+
+	switch p.Strategy {
+	case balance.LeastConnections:
+		// NOTE: Ties are broken round-robin, so an idle pool still spreads its load
+		offset := int(p.next.Add(1))
+		var best *upstream
+		for i := range p.upstreams {
+			u := p.upstreams[(offset+i)%len(p.upstreams)]
+			if candidate(u) && (best == nil || u.active.Load() < best.active.Load()) {
+				best = u
+			}
+		}
+		return best
+	case balance.ConsistentHash:
+		key := hash(p.HashKey(r))
+		start, _ := slices.BinarySearchFunc(p.ring, key, func(point ringPoint, key uint64) int {
+			switch {
+			case point.hash < key:
+				return -1
+			case point.hash > key:
+				return 1
+			}
+			return 0
+		})
+		for i := range p.ring {
+			if u := p.ring[(start+i)%len(p.ring)].upstream; candidate(u) {
+				return u
+			}
+		}
+		return nil
+	default:
+		offset := int(p.next.Add(1) - 1)
+		for i := range p.upstreams {
+			if u := p.upstreams[(offset+i)%len(p.upstreams)]; candidate(u) {
+				return u
+			}
+		}
+		return nil
+	}
+}
+
+// failed records a failure against the upstream, ejecting it once it's failed too many times in a row.
+func (p *Upstreams) failed(u *upstream, err error) {
+	if int(u.failures.Add(1)) < p.FailureThreshold {
+		return
+	}
+	u.failures.Store(0)
+	u.ejected.Store(time.Now().Add(p.Ejection).UnixNano())
+	rec.Warnf(p.name, "ejecting upstream %v for %v: %v\n", u.target, p.Ejection, err)
+}
+
+func (p *Upstreams) succeeded(u *upstream) {
+	u.failures.Store(0)
+}
+
+type attemptKey struct{}
+
+// attempt records the outcome of forwarding a request to a single upstream.
+type attempt struct {
+	err error
+}
+
+// replayable reports whether the request can safely be sent again.
+func replayable(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+	default:
+		return false
+	}
+	return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+}
+
+// ServeHTTP forwards the request to an upstream chosen by the strategy, retrying against others if it can't be reached.
+//
+// NOTE: Upgraded connections, such as websockets, are passed through untouched for as long as they remain open
+func (p *Upstreams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	retries := p.Retries
+	if retries < 0 || !replayable(r) || r.Header.Get("Upgrade") != "" {
+		retries = 0
+	}
+
+	var tried []*upstream
+	var last error
+	for len(tried) <= retries {
+		u := p.pick(r, tried)
+		if u == nil {
+			break
+		}
+		tried = append(tried, u)
+
+		if len(tried) > 1 && r.GetBody != nil {
+			body, err := r.GetBody()
+			if err != nil {
+				break
+			}
+			r.Body = body
+		}
+
+		a := &attempt{}
+		func() {
+			// NOTE: The proxy panics with http.ErrAbortHandler when a response breaks off midway
+			u.active.Add(1)
+			defer u.active.Add(-1)
+			u.proxy.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), attemptKey{}, a)))
+		}()
+
+		if a.err == nil {
+			return
+		}
+		if r.Context().Err() != nil {
+			// The client went away - which says nothing about the upstream
+			return
+		}
+		last = a.err
+		p.failed(u, a.err)
+		rec.Verbosef(p.name, "upstream %v failed: %v\n", u.target, a.err)
+	}
+
+	if last == nil {
+		http.Error(w, "no healthy upstream", http.StatusServiceUnavailable)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+}
+
+// check requests the health path of every upstream concurrently, updating each one's health.
+func (p *Upstreams) check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, u := range p.upstreams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			probe, cancel := context.WithTimeout(ctx, p.HealthTimeout)
+			defer cancel()
+
+			healthy := false
+			target := u.target.JoinPath(p.HealthPath)
+			if request, err := http.NewRequestWithContext(probe, http.MethodGet, target.String(), nil); err == nil {
+				if response, err := p.transport.RoundTrip(request); err == nil {
+					_, _ = io.Copy(io.Discard, response.Body)
+					_ = response.Body.Close()
+					healthy = response.StatusCode < 500
+				}
+			}
+			if ctx.Err() != nil {
+				// The check was abandoned as the neuron stopped, rather than failed
+				return
+			}
+
+			if was := !u.unhealthy.Swap(!healthy); was != healthy {
+				if healthy {
+					rec.Printf(p.name, "upstream %v is healthy\n", u.target)
+				} else {
+					rec.Warnf(p.name, "upstream %v is unhealthy\n", u.target)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// ReverseProxy serves the pool of upstreams on the provided address - see Upstreams.  Pair it with Net.HealthCheck
+// to actively remove failing upstreams from the pool.  Upstreams can be any HTTP server - including sub-processes
+// sparked on the same cortex, building a small platform from JanOS primitives alone:
+//
+//	pool := neural.NewUpstreams("app", neural.ProxyOptions{Strategy: balance.LeastConnections},
+//		"http://localhost:8081", "http://localhost:8082")
+//	cortex.Synapses() <- neural.Shell.SubProcess(life.Looping, "app-1", []string{"./app", "-port", "8081"})
+//	cortex.Synapses() <- neural.Shell.SubProcess(life.Looping, "app-2", []string{"./app", "-port", "8082"})
+//	cortex.Synapses() <- neural.Net.HealthCheck(life.Looping, "app-health", pool)
+//	cortex.Synapses() <- neural.Net.ReverseProxy(life.Looping, "app", ":8080", pool)
+func (_net) ReverseProxy(lifecycle life.Cycle, named string, address string, upstreams *Upstreams, onDisconnect ...func(*std.Impulse)) std.Synapse {
+	if upstreams == nil {
+		panic(errors.New("upstreams must not be nil"))
+	}
+
+	return Net.Server(lifecycle, named, address, func(imp *std.Impulse) http.Handler {
+		return upstreams
+	}, onDisconnect...)
+}
+
+// HealthCheck actively checks the health of every upstream in the pool on each activation, removing those which fail
+// from the pool until they pass again.  As a looping neuron, it checks once per cortex beat - so the cortex's
+// frequency sets how often upstreams are checked.
+func (_net) HealthCheck(lifecycle life.Cycle, named string, upstreams *Upstreams) std.Synapse {
+	if upstreams == nil {
+		panic(errors.New("upstreams must not be nil"))
+	}
+
+	return std.NewSynapse(lifecycle, named, func(imp *std.Impulse) {
+		upstreams.check(imp.Context())
+	}, nil)
+}
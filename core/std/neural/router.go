@@ -0,0 +1,138 @@
+package neural
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// A Router directs requests to handlers by their host and path, allowing a single neural server to host several
+// domains.  Patterns are split into segments - a host by its dots, and a path by its slashes - where each segment is
+// either literal, or a {name} which matches any single segment.  The final segment may instead be {name...}, which
+// matches whatever remains - including nothing at all.
+//
+//	router := neural.NewRouter()
+//	router.Handle("git.ignitelabs.net", "/{repo}/{rest...}", vanity)
+//	router.Handle("{enigma}.enigmaneering.net", "/{rest...}", enigma)
+//	router.Handle("*", "/", index)
+//	neural.Net.Server(life.Looping, "navigator", ":4242", router.Handler)
+//
+// A host of "" or "*" matches every host.  Matched segments are available to the handler through Request.PathValue,
+// and routes are consulted in the order they were added - the first match wins.  Requests matching no route are
+// answered with a 404.
+//
+// NOTE: Hosts are matched case-insensitively, and without their port.
+type Router struct {
+	routes []route
+}
+
+type route struct {
+	host      []string
+	path      []string
+	handlerFn func(imp *std.Impulse) http.Handler
+}
+
+// NewRouter creates an empty Router.  See Router
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle routes requests matching the host and path patterns to the handler which handlerFn creates for the server.
+func (rt *Router) Handle(host string, path string, handlerFn func(imp *std.Impulse) http.Handler) *Router {
+	if handlerFn == nil {
+		panic(errors.New("handler function is nil"))
+	}
+
+	r := route{handlerFn: handlerFn}
+	if host != "" && host != "*" {
+		r.host = strings.Split(strings.ToLower(host), ".")
+		sanityCheckPattern(host, r.host)
+	}
+	r.path = strings.Split(strings.TrimPrefix(path, "/"), "/")
+	sanityCheckPattern(path, r.path)
+
+	rt.routes = append(rt.routes, r)
+	return rt
+}
+
+// HandleFunc routes requests matching the host and path patterns to the provided function.
+func (rt *Router) HandleFunc(host string, path string, fn func(imp *std.Impulse, w http.ResponseWriter, r *http.Request)) *Router {
+	return rt.Handle(host, path, func(imp *std.Impulse) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fn(imp, w, r)
+		})
+	})
+}
+
+func sanityCheckPattern(pattern string, segments []string) {
+	for i, segment := range segments {
+		if strings.HasSuffix(segment, "...}") && i != len(segments)-1 {
+			panic(errors.New("'" + pattern + "' may only capture the remainder in its final segment"))
+		}
+	}
+}
+
+// Handler creates the router's handler for the provided server - creating each route's handler in turn.
+func (rt *Router) Handler(imp *std.Impulse) http.Handler {
+	handlers := make([]http.Handler, len(rt.routes))
+	for i, r := range rt.routes {
+		handlers[i] = r.handlerFn(imp)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		host := req.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		hostSegments := strings.Split(strings.ToLower(strings.TrimSuffix(host, ".")), ".")
+		pathSegments := strings.Split(strings.TrimPrefix(req.URL.Path, "/"), "/")
+
+		for i, r := range rt.routes {
+			values := make(map[string]string)
+			if r.host != nil && !match(r.host, hostSegments, ".", values) {
+				continue
+			}
+			if !match(r.path, pathSegments, "/", values) {
+				continue
+			}
+
+			for name, value := range values {
+				req.SetPathValue(name, value)
+			}
+			handlers[i].ServeHTTP(w, req)
+			return
+		}
+		http.NotFound(w, req)
+	})
+}
+
+// match reports whether the segments satisfy the pattern, capturing any named segments into values.
+func match(pattern []string, segments []string, separator string, values map[string]string) bool {
+	for i, p := range pattern {
+		if name, ok := strings.CutSuffix(p, "...}"); ok && strings.HasPrefix(name, "{") {
+			if i < len(segments) {
+				values[name[1:]] = strings.Join(segments[i:], separator)
+			} else {
+				values[name[1:]] = ""
+			}
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if segments[i] == "" {
+				return false
+			}
+			values[p[1:len(p)-1]] = segments[i]
+			continue
+		}
+		if p != segments[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
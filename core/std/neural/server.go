@@ -2,31 +2,179 @@ package neural
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"git.ignitelabs.net/janos/core"
 	"git.ignitelabs.net/janos/core/enum/life"
 	"git.ignitelabs.net/janos/core/std"
 	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/watch"
+	"golang.org/x/crypto/acme/autocert"
 )
 
+// ServerOptions configures how a neural server listens and shuts down.  The zero value serves plain HTTP/1.1 without
+// any timeouts, and drains for up to 10 seconds when stopping.
+type ServerOptions struct {
+	// CertFile and KeyFile enable TLS - with HTTP/2 negotiated automatically.  Both files are watched, and the
+	// certificate is reloaded whenever either changes - allowing certificates to be renewed without a restart.  If the
+	// pair can't be loaded when the server activates, it isn't re-activated until either file changes.
+	CertFile string
+	KeyFile  string
+
+	// AutoCertHosts enables TLS through certificates automatically provisioned and renewed by ACME (Let's Encrypt) for
+	// the listed host names - which can't be combined with a CertFile and KeyFile.  Certificates are cached within
+	// AutoCertCache, which defaults to an 'autocert' directory within the working directory, and AutoCertEmail is
+	// optionally provided to the certificate authority as a contact for any problems.
+	//
+	// NOTE: Hosts are verified through the TLS-ALPN challenge, which the certificate authority performs against port 443
+	// of each host - so the server must be reachable there.
+	AutoCertHosts []string
+	AutoCertCache string
+	AutoCertEmail string
+
+	// H2C serves HTTP/2 over plain text, alongside HTTP/1.1 - useful behind a proxy which terminates TLS itself.
+	H2C bool
+
+	// ReadTimeout, ReadHeaderTimeout, WriteTimeout and IdleTimeout are handed to the http.Server.  Zero means no timeout.
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+
+	// DrainTimeout is how long in-flight requests are given to finish when the server stops, before their connections
+	// are closed.  Zero defaults to 10 seconds.
+	DrainTimeout time.Duration
+}
+
+// Server sparks an HTTP server on the provided address, stopping together with the cortex.  See ServerWith
 func (_net) Server(lifecycle life.Cycle, named string, address string, handlerFn func(imp *std.Impulse) http.Handler, onDisconnect ...func(*std.Impulse)) std.Synapse {
+	return Net.ServerWith(lifecycle, named, address, ServerOptions{}, handlerFn, onDisconnect...)
+}
+
+// ServerWith sparks an HTTP server on the provided address, configured by the provided options.  See ServerOptions
+func (_net) ServerWith(lifecycle life.Cycle, named string, address string, options ServerOptions, handlerFn func(imp *std.Impulse) http.Handler, onDisconnect ...func(*std.Impulse)) std.Synapse {
 	if handlerFn == nil {
 		panic(errors.New("handler function is nil"))
 	}
+	if (options.CertFile == "") != (options.KeyFile == "") {
+		panic(errors.New("both a certificate and key file are required for TLS"))
+	}
+	if options.CertFile != "" && len(options.AutoCertHosts) > 0 {
+		panic(errors.New("a certificate file can't be combined with automatic certificates"))
+	}
+	if len(options.AutoCertHosts) > 0 && options.AutoCertCache == "" {
+		options.AutoCertCache = "autocert"
+	}
+	if options.DrainTimeout <= 0 {
+		options.DrainTimeout = 10 * time.Second
+	}
+
+	shutdown := func(server *http.Server) {
+		ctx, cancel := context.WithTimeout(context.Background(), options.DrainTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			_ = server.Close()
+		}
+	}
+
+	// NOTE: The manager outlives each activation, so a re-activated server doesn't lose certificates mid-renewal
+	var manager *autocert.Manager
+	if len(options.AutoCertHosts) > 0 {
+		manager = &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(options.AutoCertHosts...),
+			Cache:      autocert.DirCache(options.AutoCertCache),
+			Email:      options.AutoCertEmail,
+		}
+	}
+
+	// NOTE: The synapse can be sparked into several cortices, each of which runs its own server through its own impulse
+	var servers sync.Map
+	serving := func(imp *std.Impulse) *served {
+		s, _ := servers.LoadOrStore(imp, &served{})
+		return s.(*served)
+	}
 
 	return std.NewSynapse(lifecycle, named, func(imp *std.Impulse) {
+		state := serving(imp)
 		server := &http.Server{
-			Addr:    address,
-			Handler: handlerFn(imp),
+			Addr:              address,
+			Handler:           handlerFn(imp),
+			ReadTimeout:       options.ReadTimeout,
+			ReadHeaderTimeout: options.ReadHeaderTimeout,
+			WriteTimeout:      options.WriteTimeout,
+			IdleTimeout:       options.IdleTimeout,
+			BaseContext: func(net.Listener) context.Context {
+				return imp.Context()
+			},
+		}
+
+		if options.H2C {
+			server.Protocols = new(http.Protocols)
+			server.Protocols.SetHTTP1(true)
+			server.Protocols.SetUnencryptedHTTP2(true)
+			if options.CertFile != "" || manager != nil {
+				server.Protocols.SetHTTP2(true)
+			}
+		}
+
+		var watchers []*watch.Watcher
+		if manager != nil {
+			server.TLSConfig = manager.TLSConfig()
+			server.TLSConfig.MinVersion = tls.VersionTLS12
+		} else if options.CertFile != "" {
+			var err error
+			server.TLSConfig, watchers, err = reloadingTLS(imp, options.CertFile, options.KeyFile)
+			if err != nil {
+				rec.Errorf(imp.Bridge.String(), "failed to load certificate - waiting for the certificate or key to change: %v\n", err)
+				state.failed.Store(core.Ref(fingerprint(options.CertFile, options.KeyFile)))
+				if onDisconnect != nil && onDisconnect[0] != nil {
+					onDisconnect[0](imp)
+				}
+				return
+			}
 		}
 
 		imp.Thought = std.NewThought(server)
+		state.failed.Store(nil)
+		state.running.Store(server)
+		stopped := make(chan any)
+
+		go func() {
+			// Stop serving together with the cortex
+			select {
+			case <-imp.Context().Done():
+				shutdown(server)
+			case <-stopped:
+			}
+		}()
 
 		go func() {
+			defer close(stopped)
+			defer func() {
+				for _, w := range watchers {
+					_ = w.Close()
+				}
+			}()
 			rec.Printf(imp.Bridge.String(), "neural server listening on %s\n", address)
 
-			if err := server.ListenAndServe(); err != nil {
+			var err error
+			if server.TLSConfig != nil {
+				// NOTE: The certificate is provided by the TLS config, which keeps it current - or provisions it
+				err = server.ListenAndServeTLS("", "")
+			} else {
+				err = server.ListenAndServe()
+			}
+			if err != nil {
 				if errors.Is(err, http.ErrServerClosed) {
 					rec.Printf(imp.Bridge.String(), "%s\n", err)
 				} else {
@@ -37,19 +185,109 @@ func (_net) Server(lifecycle life.Cycle, named string, address string, handlerFn
 				}
 			}
 
-			imp.Thought = nil
+			state.running.Store(nil)
 		}()
 	}, func(imp *std.Impulse) bool {
-		if imp.Thought == nil {
-			return true
+		state := serving(imp)
+		if failed := state.failed.Load(); failed != nil && *failed == fingerprint(options.CertFile, options.KeyFile) {
+			return false
 		}
-		return false
+		return state.running.Load() == nil
 	}, func(imp *std.Impulse) {
-		if imp.Thought != nil {
-			imp.Thought.Gate.Lock()
-			defer imp.Thought.Gate.Unlock()
-
-			_ = imp.Thought.Revelation.(*http.Server).Shutdown(context.Background())
+		if s, ok := servers.LoadAndDelete(imp); ok {
+			if server := s.(*served).running.Load(); server != nil {
+				shutdown(server)
+			}
 		}
 	})
 }
+
+// served holds the state of a single impulse's server.  Both fields are held atomically, as the serving goroutine
+// clears the running server once it stops.
+type served struct {
+	running atomic.Pointer[http.Server]
+
+	// failed holds the fingerprint of the certificate and key which couldn't be loaded, so that the server isn't
+	// re-activated until either changes.
+	failed atomic.Pointer[string]
+}
+
+// fingerprint identifies the current state of the provided files through their size and modification time.
+func fingerprint(files ...string) string {
+	var out strings.Builder
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			fmt.Fprintf(&out, "%d@%d;", info.Size(), info.ModTime().UnixNano())
+		} else {
+			out.WriteString("missing;")
+		}
+	}
+	return out.String()
+}
+
+// reloadingTLS loads the certificate pair into a TLS config, then watches both files - swapping in the renewed pair
+// whenever either changes.  If a renewed pair can't be loaded, the prior certificate continues to be served.
+func reloadingTLS(imp *std.Impulse, certFile string, keyFile string) (*tls.Config, []*watch.Watcher, error) {
+	var current atomic.Pointer[tls.Certificate]
+	load := func() error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		current.Store(&cert)
+		return nil
+	}
+	if err := load(); err != nil {
+		return nil, nil, err
+	}
+
+	var watchers []*watch.Watcher
+	for _, file := range []string{certFile, keyFile} {
+		// NOTE: Renewals often write the pair as two separate files, so the reload waits for both to settle
+		w, err := watch.OnChange(file, func(watch.Event) {
+			if err := load(); err != nil {
+				rec.Warnf(imp.Bridge.String(), "failed to reload certificate: %v\n", err)
+				return
+			}
+			rec.Printf(imp.Bridge.String(), "reloaded certificate\n")
+		}, watch.Options{Debounce: 250 * time.Millisecond})
+		if err != nil {
+			rec.Warnf(imp.Bridge.String(), "failed to watch '%v' - the certificate won't be reloaded: %v\n", file, err)
+			continue
+		}
+		watchers = append(watchers, w)
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return current.Load(), nil
+		},
+	}, watchers, nil
+}
+
+// subscription holds whatever a server's handler function subscribes to, such as a file watcher, so that re-activating
+// the server releases the prior subscription rather than accumulating another.  The subscription is also released once
+// the impulse's context is done.
+type subscription struct {
+	gate    sync.Mutex
+	release func()
+}
+
+// replace releases the prior subscription, then holds the provided cancel function until it's replaced in turn.
+func (s *subscription) replace(imp *std.Impulse, cancel func()) {
+	var once sync.Once
+	release := func() { once.Do(cancel) }
+	stop := context.AfterFunc(imp.Context(), release)
+
+	s.gate.Lock()
+	defer s.gate.Unlock()
+
+	if s.release != nil {
+		s.release()
+	}
+	s.release = func() {
+		stop()
+		release()
+	}
+}
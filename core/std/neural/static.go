@@ -0,0 +1,261 @@
+package neural
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/watch"
+)
+
+// StaticOptions configures how a static site is served.  The zero value serves index.html files for directories, and
+// a plain 404 for anything missing.
+type StaticOptions struct {
+	// Index is the file served for a directory - defaulting to "index.html".
+	Index string
+
+	// SPA serves the root index in place of any missing path without a file extension, allowing a single page
+	// application to route on the client.  Missing paths with an extension are still treated as missing assets.
+	SPA bool
+
+	// NotFound is the path of a page, within the file system, served with every 404.
+	NotFound string
+
+	// CacheControl sets the Cache-Control header of matching files - the first matching rule applies.
+	CacheControl []CacheRule
+
+	// Dev serves the directory at this path on disk, in place of the provided file system, watching it for changes -
+	// every response is marked "no-cache", so edits are picked up on the next request.
+	Dev string
+}
+
+// A CacheRule sets the Cache-Control header of the files matching its pattern.  A pattern without a slash is matched
+// against the file's name, while a pattern with one is matched against its whole path - see path.Match
+//
+//	neural.CacheRule{Pattern: "*.html", Value: "no-cache"}
+//	neural.CacheRule{Pattern: "assets/*", Value: "public, max-age=31536000, immutable"}
+type CacheRule struct {
+	Pattern string
+	Value   string
+}
+
+// Static serves the provided file system - see StaticHandler.
+func (_net) Static(lifecycle life.Cycle, named string, address string, fsys fs.FS, options StaticOptions, onDisconnect ...func(*std.Impulse)) std.Synapse {
+	return Net.Server(lifecycle, named, address, StaticHandler(fsys, options), onDisconnect...)
+}
+
+// StaticHandler serves the provided file system, such as an embed.FS.  Every file is served with a strong ETag, and a
+// Last-Modified header when the file system provides one, so clients can revalidate cheaply.  If a client accepts
+// them, precompressed variants are served in place of the original - 'app.js.br' or 'app.js.gz' for 'app.js'.
+//
+// NOTE: The handler function fits Net.Server, HTTP.Chain and Router.Handle alike.
+func StaticHandler(fsys fs.FS, options StaticOptions) func(imp *std.Impulse) http.Handler {
+	if fsys == nil && options.Dev == "" {
+		panic(errors.New("file system is nil"))
+	}
+	if options.Index == "" {
+		options.Index = "index.html"
+	}
+	for _, rule := range options.CacheControl {
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			panic(err)
+		}
+	}
+
+	// NOTE: Each re-activation of a server rebuilds its handler, replacing the prior watcher
+	var watching subscription
+
+	return func(imp *std.Impulse) http.Handler {
+		s := &static{fsys: fsys, StaticOptions: options, tags: make(map[string]etag)}
+
+		if options.Dev != "" {
+			s.fsys = os.DirFS(options.Dev)
+			w, err := watch.OnChange(options.Dev, func(e watch.Event) {
+				rec.Verbosef(imp.Bridge.String(), "%v %v\n", e.Change, e.Path)
+				s.forget()
+			}, watch.Options{Recursive: true, Debounce: 50 * time.Millisecond})
+			if err != nil {
+				rec.Warnf(imp.Bridge.String(), "failed to watch '%v' - changes won't be picked up: %v\n", options.Dev, err)
+			} else {
+				watching.replace(imp, func() {
+					_ = w.Close()
+				})
+			}
+		}
+		return s
+	}
+}
+
+// static serves a file system, remembering the ETag of every file it's served.
+type static struct {
+	StaticOptions
+	fsys fs.FS
+
+	gate sync.Mutex
+	tags map[string]etag
+}
+
+// etag is a file's remembered ETag, along with the modification time and size it was computed against.
+type etag struct {
+	modified time.Time
+	size     int64
+	tag      string
+}
+
+// forget discards every remembered ETag, as the files have changed.
+func (s *static) forget() {
+	s.gate.Lock()
+	defer s.gate.Unlock()
+	clear(s.tags)
+}
+
+// encodings lists the precompressed variants, in order of preference.
+var encodings = []struct {
+	name      string
+	extension string
+}{{"br", ".br"}, {"gzip", ".gz"}}
+
+func (s *static) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	info, err := fs.Stat(s.fsys, name)
+	if err == nil && info.IsDir() {
+		// NOTE: Relative links within an index only resolve correctly beneath a trailing slash
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			target := r.URL.Path + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return
+		}
+		name = path.Join(name, s.Index)
+		info, err = fs.Stat(s.fsys, name)
+	}
+
+	if err != nil || info.IsDir() {
+		if s.SPA && path.Ext(name) == "" {
+			name = s.Index
+		} else {
+			s.missing(w, r)
+			return
+		}
+	}
+
+	if !s.serve(w, r, name, http.StatusOK) {
+		s.missing(w, r)
+	}
+}
+
+// missing answers with the custom 404 page, if there is one.
+func (s *static) missing(w http.ResponseWriter, r *http.Request) {
+	if s.NotFound == "" || !s.serve(w, r, strings.TrimPrefix(s.NotFound, "/"), http.StatusNotFound) {
+		http.NotFound(w, r)
+	}
+}
+
+// serve writes the named file - or its best precompressed variant - reporting false if it couldn't be read.
+func (s *static) serve(w http.ResponseWriter, r *http.Request, name string, status int) bool {
+	header := w.Header()
+	header.Add("Vary", "Accept-Encoding")
+
+	file, encoding := name, ""
+	for _, e := range encodings {
+		if !accepts(r, e.name) {
+			continue
+		}
+		if info, err := fs.Stat(s.fsys, name+e.extension); err == nil && !info.IsDir() {
+			file, encoding = name+e.extension, e.name
+			break
+		}
+	}
+
+	data, err := fs.ReadFile(s.fsys, file)
+	if err != nil {
+		return false
+	}
+	var modified time.Time
+	size := int64(len(data))
+	if info, err := fs.Stat(s.fsys, file); err == nil {
+		modified, size = info.ModTime(), info.Size()
+	}
+
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		header.Set("Content-Type", contentType)
+	} else {
+		header.Set("Content-Type", http.DetectContentType(data))
+	}
+	if encoding != "" {
+		header.Set("Content-Encoding", encoding)
+	}
+	if value := s.cacheControl(name); value != "" {
+		header.Set("Cache-Control", value)
+	}
+
+	if status != http.StatusOK {
+		// NOTE: ServeContent would answer conditional requests with a 304, hiding that the page is missing
+		header.Del("Accept-Ranges")
+		w.WriteHeader(status)
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(data)
+		}
+		return true
+	}
+
+	header.Set("ETag", s.etag(file, modified, size, data))
+	http.ServeContent(w, r, name, modified, bytes.NewReader(data))
+	return true
+}
+
+// etag returns the strong ETag of the file's contents, remembering it for as long as the file's modification time and
+// size stay the same.
+func (s *static) etag(name string, modified time.Time, size int64, data []byte) string {
+	s.gate.Lock()
+	defer s.gate.Unlock()
+
+	if remembered, ok := s.tags[name]; ok && remembered.modified.Equal(modified) && remembered.size == size {
+		return remembered.tag
+	}
+	sum := sha256.Sum256(data)
+	tag := `"` + hex.EncodeToString(sum[:12]) + `"`
+	s.tags[name] = etag{modified, size, tag}
+	return tag
+}
+
+// cacheControl returns the Cache-Control value of the first rule matching the file.
+func (s *static) cacheControl(name string) string {
+	if s.Dev != "" {
+		return "no-cache"
+	}
+	for _, rule := range s.CacheControl {
+		target := name
+		if !strings.Contains(rule.Pattern, "/") {
+			target = path.Base(name)
+		}
+		if ok, _ := path.Match(rule.Pattern, target); ok {
+			return rule.Value
+		}
+	}
+	return ""
+}
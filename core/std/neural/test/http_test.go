@@ -0,0 +1,205 @@
+package test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/std/neural"
+)
+
+// serve builds the handler function for a bare impulse, then serves the provided request through it.
+func serve(handlerFn func(*std.Impulse) http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handlerFn(&std.Impulse{Bridge: std.Bridge{"test"}}).ServeHTTP(w, r)
+	return w
+}
+
+func text(body string) func(*std.Impulse) http.Handler {
+	return func(*std.Impulse) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, body)
+		})
+	}
+}
+
+func Test_HTTP_Chain_Order(t *testing.T) {
+	var order []string
+	layer := func(name string) neural.Middleware {
+		return func(imp *std.Impulse, next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	serve(neural.HTTP.Chain(text("ok"), layer("outer"), layer("inner")), httptest.NewRequest("GET", "/", nil))
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Fatalf("expected the first middleware to be outermost, got %v", order)
+	}
+}
+
+func Test_HTTP_RequestID(t *testing.T) {
+	var seen uint64
+	handler := func(*std.Impulse) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen, _ = neural.RequestIDOf(r)
+		})
+	}
+
+	w := serve(neural.HTTP.Chain(handler, neural.HTTP.RequestID(), neural.HTTP.Logging()), httptest.NewRequest("GET", "/", nil))
+	header, err := strconv.ParseUint(w.Header().Get(neural.RequestIDHeader), 10, 64)
+	if err != nil || header != seen || seen == 0 {
+		t.Fatalf("expected the echoed identifier %v to match the request's %v", header, seen)
+	}
+}
+
+func Test_HTTP_Recovery(t *testing.T) {
+	handler := func(*std.Impulse) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})
+	}
+
+	w := serve(neural.HTTP.Chain(handler, neural.HTTP.Recovery()), httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected a 500, got %d", w.Code)
+	}
+}
+
+func Test_HTTP_Gzip(t *testing.T) {
+	body := strings.Repeat("compress me ", 100)
+	handlerFn := neural.HTTP.Chain(text(body), neural.HTTP.Gzip())
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "br, gzip")
+	w := serve(handlerFn, r)
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("expected a gzipped response")
+	}
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _ := io.ReadAll(reader)
+	if string(decoded) != body {
+		t.Fatal("the decompressed body didn't match")
+	}
+
+	// Clients which don't accept gzip receive the plain body
+	w = serve(handlerFn, httptest.NewRequest("GET", "/", nil))
+	if w.Header().Get("Content-Encoding") != "" || w.Body.String() != body {
+		t.Fatal("expected an uncompressed response")
+	}
+}
+
+func Test_HTTP_Gzip_Partial(t *testing.T) {
+	body := strings.Repeat("compress me ", 100)
+	handlerFn := neural.HTTP.Chain(func(*std.Impulse) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "body.txt", time.Time{}, strings.NewReader(body))
+		})
+	}, neural.HTTP.Gzip())
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	r.Header.Set("Range", "bytes=12-22")
+	w := serve(handlerFn, r)
+	if w.Code != http.StatusPartialContent {
+		t.Fatalf("expected a partial response, got %d", w.Code)
+	}
+	if w.Header().Get("Content-Encoding") != "" {
+		t.Fatal("expected the partial response to be left uncompressed")
+	}
+	if w.Body.String() != body[12:23] || w.Header().Get("Content-Range") != "bytes 12-22/1200" {
+		t.Fatalf("expected the requested range, got %q as %v", w.Body.String(), w.Header().Get("Content-Range"))
+	}
+}
+
+func Test_HTTP_CORS(t *testing.T) {
+	handlerFn := neural.HTTP.Chain(text("ok"), neural.HTTP.CORS(neural.CORS{
+		Origins: []string{"https://allowed.example"},
+		Headers: []string{"Content-Type"},
+		MaxAge:  time.Hour,
+	}))
+
+	preflight := httptest.NewRequest("OPTIONS", "/", nil)
+	preflight.Header.Set("Origin", "https://allowed.example")
+	preflight.Header.Set("Access-Control-Request-Method", "POST")
+	w := serve(handlerFn, preflight)
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "https://allowed.example" {
+		t.Fatalf("expected the preflight to be answered, got %d", w.Code)
+	}
+	if w.Header().Get("Access-Control-Max-Age") != "3600" {
+		t.Fatalf("expected a max age of an hour, got %v", w.Header().Get("Access-Control-Max-Age"))
+	}
+
+	denied := httptest.NewRequest("GET", "/", nil)
+	denied.Header.Set("Origin", "https://denied.example")
+	w = serve(handlerFn, denied)
+	if w.Header().Get("Access-Control-Allow-Origin") != "" || w.Body.String() != "ok" {
+		t.Fatal("expected other origins to be handed on undecorated")
+	}
+}
+
+func Test_HTTP_RateLimit(t *testing.T) {
+	handler := neural.HTTP.Chain(text("ok"), neural.HTTP.RateLimit(1, 2))(&std.Impulse{})
+
+	request := func(addr string) int {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if request("10.0.0.1:1") != 200 || request("10.0.0.1:2") != 200 {
+		t.Fatal("expected the burst to be allowed")
+	}
+	if request("10.0.0.1:3") != http.StatusTooManyRequests {
+		t.Fatal("expected the request beyond the burst to be refused")
+	}
+	if request("10.0.0.2:1") != 200 {
+		t.Fatal("expected other clients to be limited independently")
+	}
+}
+
+func Test_HTTP_Router(t *testing.T) {
+	echo := func(name string) func(*std.Impulse) http.Handler {
+		return func(*std.Impulse) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, name+":"+r.PathValue("sub")+":"+r.PathValue("repo")+":"+r.PathValue("rest"))
+			})
+		}
+	}
+
+	router := neural.NewRouter().
+		Handle("git.example.net", "/{repo}/{rest...}", echo("git")).
+		Handle("{sub}.example.net", "/", echo("sub")).
+		Handle("*", "/{rest...}", echo("any"))
+
+	cases := map[string]string{
+		"http://git.example.net/core/std/neural": "git::core:std/neural",
+		"http://GIT.example.net:8080/core":       "git::core:",
+		"http://e1s2.example.net/":               "sub:e1s2::",
+		"http://e1s2.example.net/deeper":         "any:::deeper",
+		"http://elsewhere.org/a/b":               "any:::a/b",
+	}
+	for url, expected := range cases {
+		if body := serve(router.Handler, httptest.NewRequest("GET", url, nil)).Body.String(); body != expected {
+			t.Errorf("%v: expected %q, got %q", url, expected, body)
+		}
+	}
+
+	unrouted := neural.NewRouter().Handle("example.net", "/", echo("root"))
+	if w := serve(unrouted.Handler, httptest.NewRequest("GET", "http://other.net/", nil)); w.Code != http.StatusNotFound {
+		t.Fatalf("expected unrouted requests to 404, got %d", w.Code)
+	}
+}
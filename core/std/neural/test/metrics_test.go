@@ -0,0 +1,91 @@
+package test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/std/neural"
+)
+
+// address returns a loopback address which was free at the time of calling.
+func address(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+// fetch retries the request until the server answers, failing the test after five seconds.
+func fetch(t *testing.T, client *http.Client, url string) (*http.Response, string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		response, err := client.Get(url)
+		if err == nil {
+			body, err := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return response, string(body)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func Test_Metrics_Exposition(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	measured := std.NewCortexWithClock(`measured "quoted"`, clock)
+	measured.Frequency = 10 //hz
+	defer measured.Shutdown()
+
+	metrics := std.NewMetrics()
+	measured.Measure(metrics)
+	measured.Synapses() <- std.NewSynapse(life.Looping, "steady", func(imp *std.Impulse) {}, nil)
+	if err := measured.Spark(); err != nil {
+		t.Fatal(err)
+	}
+	clock.Step(measured)
+	clock.Step(measured, 2)
+
+	host := address(t)
+	server := std.NewCortex("server")
+	server.Frequency = 1
+	defer server.Shutdown()
+	if err := server.Spark(neural.Net.Metrics(life.Looping, "metrics", host, metrics)); err != nil {
+		t.Fatal(err)
+	}
+
+	response, body := fetch(t, http.DefaultClient, "http://"+host+"/metrics")
+	if kind := response.Header.Get("Content-Type"); !strings.HasPrefix(kind, "text/plain; version=0.0.4") {
+		t.Errorf("expected the Prometheus text format, got %q", kind)
+	}
+
+	for _, line := range []string{
+		"# HELP janos_cortex_beats_total Beats fired by the cortex.",
+		"# TYPE janos_cortex_beats_total counter",
+		`janos_cortex_beats_total{cortex="measured \"quoted\""} 3`,
+		"# TYPE janos_cortex_frequency_hertz gauge",
+		`janos_cortex_frequency_hertz{cortex="measured \"quoted\""} 10`,
+		`janos_cortex_beat_period_seconds{cortex="measured \"quoted\""} 0.1`,
+		`janos_cortex_dropped_signals_total{cortex="measured \"quoted\"",channel="impulses"} 0`,
+		`janos_neuron_activations_total{cortex="measured \"quoted\"",neuron="steady",bridge="measured \"quoted\" ⇝ steady"} 3`,
+		`janos_neuron_panics_total{cortex="measured \"quoted\"",neuron="steady",bridge="measured \"quoted\" ⇝ steady"} 0`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected the exposition to contain %q, got:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/balance"
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/std/neural"
+)
+
+// upstream starts a server answering every request with its name - and its health path with a 503 once sick.
+func upstream(t *testing.T, name string, sick *atomic.Bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" && sick != nil && sick.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, name)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// dead returns the address of a server which is no longer listening.
+func dead() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func through(t *testing.T, handler http.Handler, header ...string) (int, string) {
+	t.Helper()
+	r := httptest.NewRequest("GET", "/", nil)
+	if len(header) == 2 {
+		r.Header.Set(header[0], header[1])
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w.Code, w.Body.String()
+}
+
+func Test_ReverseProxy_RoundRobin(t *testing.T) {
+	pool := neural.NewUpstreams("pool", neural.ProxyOptions{},
+		upstream(t, "a", nil).URL, upstream(t, "b", nil).URL, upstream(t, "c", nil).URL)
+
+	counts := make(map[string]int)
+	for i := 0; i < 6; i++ {
+		_, body := through(t, pool)
+		counts[body]++
+	}
+	if counts["a"] != 2 || counts["b"] != 2 || counts["c"] != 2 {
+		t.Fatalf("expected requests to be spread evenly, got %v", counts)
+	}
+}
+
+func Test_ReverseProxy_ConsistentHash(t *testing.T) {
+	pool := neural.NewUpstreams("pool", neural.ProxyOptions{
+		Strategy: balance.ConsistentHash,
+		HashKey:  func(r *http.Request) string { return r.Header.Get("X-Key") },
+	}, upstream(t, "a", nil).URL, upstream(t, "b", nil).URL, upstream(t, "c", nil).URL)
+
+	_, first := through(t, pool, "X-Key", "client")
+	for i := 0; i < 10; i++ {
+		if _, body := through(t, pool, "X-Key", "client"); body != first {
+			t.Fatalf("expected the same key to reach the same upstream, got %v then %v", first, body)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		_, body := through(t, pool, "X-Key", "client-"+strconv.Itoa(i))
+		seen[body] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected keys to be spread across every upstream, got %v", seen)
+	}
+}
+
+func Test_ReverseProxy_LeastConnections(t *testing.T) {
+	release := make(chan any)
+	busy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = io.WriteString(w, "busy")
+	}))
+	defer busy.Close()
+	defer close(release)
+
+	pool := neural.NewUpstreams("pool", neural.ProxyOptions{Strategy: balance.LeastConnections}, busy.URL, upstream(t, "idle", nil).URL)
+
+	// Occupy whichever upstream the first request reaches, until the busy one holds a connection
+	for pool.Status()[0].Active == 0 {
+		go through(t, pool)
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 0; i < 5; i++ {
+		if _, body := through(t, pool); body != "idle" {
+			t.Fatalf("expected requests to avoid the busy upstream, got %v", body)
+		}
+	}
+}
+
+func Test_ReverseProxy_Aborted(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		_, _ = io.WriteString(w, "partial")
+		http.NewResponseController(w).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer broken.Close()
+
+	pool := neural.NewUpstreams("pool", neural.ProxyOptions{}, broken.URL)
+
+	// NOTE: Beneath an http.Server, the proxy aborts the handler with a panic once a response breaks off midway
+	proxy := httptest.NewServer(pool)
+	defer proxy.Close()
+	if response, err := http.Get(proxy.URL); err == nil {
+		_, err = io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err == nil {
+			t.Fatal("expected the broken response to be cut off")
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.Status()[0].Active != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the aborted connection to be released, %d remain active", pool.Status()[0].Active)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func Test_ReverseProxy_Ejection(t *testing.T) {
+	pool := neural.NewUpstreams("pool", neural.ProxyOptions{FailureThreshold: 1, Ejection: time.Hour},
+		dead(), upstream(t, "alive", nil).URL)
+
+	for i := 0; i < 4; i++ {
+		if code, body := through(t, pool); code != 200 || body != "alive" {
+			t.Fatalf("expected the failed request to be retried, got %d %v", code, body)
+		}
+	}
+	if status := pool.Status(); !status[0].Ejected || status[1].Ejected {
+		t.Fatalf("expected only the dead upstream to be ejected, got %+v", status)
+	}
+}
+
+func Test_ReverseProxy_NoRetry(t *testing.T) {
+	pool := neural.NewUpstreams("pool", neural.ProxyOptions{Ejection: time.Hour}, dead(), dead())
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader("payload"))
+	w := httptest.NewRecorder()
+	pool.ServeHTTP(w, r)
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("expected an unreplayable request to fail without retrying, got %d", w.Code)
+	}
+
+	pool = neural.NewUpstreams("pool", neural.ProxyOptions{FailureThreshold: 1, Ejection: time.Hour}, dead())
+	through(t, pool)
+	if code, _ := through(t, pool); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 once every upstream is ejected, got %d", code)
+	}
+}
+
+func Test_ReverseProxy_WebSocket(t *testing.T) {
+	echo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			http.Error(w, "upgrade required", http.StatusUpgradeRequired)
+			return
+		}
+		conn, buffer, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = buffer.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		_ = buffer.Flush()
+		_, _ = io.Copy(conn, buffer)
+	}))
+	defer echo.Close()
+
+	proxy := httptest.NewServer(neural.NewUpstreams("pool", neural.ProxyOptions{}, echo.URL))
+	defer proxy.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(proxy.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, _ = io.WriteString(conn, "GET / HTTP/1.1\r\nHost: proxy\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil || response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected the upgrade to pass through, got %v %v", response, err)
+	}
+
+	_, _ = io.WriteString(conn, "ping")
+	echoed := make([]byte, 4)
+	if _, err = io.ReadFull(reader, echoed); err != nil || string(echoed) != "ping" {
+		t.Fatalf("expected the upgraded connection to echo, got %q %v", echoed, err)
+	}
+}
+
+func Test_ReverseProxy_HealthCheck(t *testing.T) {
+	var sick atomic.Bool
+	pool := neural.NewUpstreams("pool", neural.ProxyOptions{HealthPath: "/health"},
+		upstream(t, "flaky", &sick).URL, upstream(t, "steady", nil).URL)
+
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	cortex := std.NewCortexWithClock("proxy", clock)
+	cortex.Frequency = 1
+	defer cortex.Shutdown()
+	if err := cortex.Spark(neural.Net.HealthCheck(life.Looping, "health", pool)); err != nil {
+		t.Fatal(err)
+	}
+	clock.Step(cortex)
+
+	sick.Store(true)
+	clock.Step(cortex)
+	if status := pool.Status(); status[0].Healthy || !status[1].Healthy {
+		t.Fatalf("expected only the sick upstream to be unhealthy, got %+v", status)
+	}
+	for i := 0; i < 4; i++ {
+		if _, body := through(t, pool); body != "steady" {
+			t.Fatalf("expected the unhealthy upstream to be left out, got %v", body)
+		}
+	}
+
+	sick.Store(false)
+	clock.Step(cortex)
+	if !pool.Status()[0].Healthy {
+		t.Fatal("expected the upstream to rejoin once healthy")
+	}
+}
@@ -0,0 +1,283 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/std/neural"
+)
+
+// certify writes a freshly generated self-signed certificate pair for the provided common name.
+func certify(t *testing.T, certFile string, keyFile string, name string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: The key is written first, so the pair only ever mismatches until the certificate follows
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: private}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// serveWith sparks a neural server with the provided options, answering every request with its protocol.
+func serveWith(t *testing.T, options neural.ServerOptions, handler ...http.HandlerFunc) (*std.Cortex, string) {
+	t.Helper()
+	host := address(t)
+	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+	if len(handler) > 0 {
+		fn = handler[0]
+	}
+
+	cortex := std.NewCortex("server")
+	cortex.Frequency = 1
+	err := cortex.Spark(neural.Net.ServerWith(life.Looping, "server", host, options, func(*std.Impulse) http.Handler {
+		return fn
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cortex, host
+}
+
+func Test_Server_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	certify(t, certFile, keyFile, "first")
+
+	cortex, host := serveWith(t, neural.ServerOptions{CertFile: certFile, KeyFile: keyFile})
+	defer cortex.Shutdown()
+
+	transport := &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Transport: transport}
+
+	served := func() (string, string) {
+		transport.CloseIdleConnections()
+		response, body := fetch(t, client, "https://"+host+"/")
+		return response.TLS.PeerCertificates[0].Subject.CommonName, body
+	}
+
+	name, proto := served()
+	if name != "first" {
+		t.Fatalf("expected the first certificate, got %q", name)
+	}
+	if proto != "HTTP/2.0" {
+		t.Errorf("expected HTTP/2 to be negotiated over TLS, got %v", proto)
+	}
+
+	certify(t, certFile, keyFile, "second")
+	deadline := time.Now().Add(5 * time.Second)
+	for name, _ = served(); name != "second"; name, _ = served() {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the renewed certificate to be served without a restart, got %q", name)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func Test_Server_H2C(t *testing.T) {
+	cortex, host := serveWith(t, neural.ServerOptions{H2C: true})
+	defer cortex.Shutdown()
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	transport := &http.Transport{Protocols: protocols}
+	defer transport.CloseIdleConnections()
+
+	if _, proto := fetch(t, &http.Client{Transport: transport}, "http://"+host+"/"); proto != "HTTP/2.0" {
+		t.Errorf("expected HTTP/2 over plain text, got %v", proto)
+	}
+	if _, proto := fetch(t, http.DefaultClient, "http://"+host+"/"); proto != "HTTP/1.1" {
+		t.Errorf("expected HTTP/1.1 to still be served, got %v", proto)
+	}
+}
+
+func Test_Server_Drain(t *testing.T) {
+	arrived := make(chan any, 2)
+	release := make(chan any)
+	defer close(release)
+	cortex, host := serveWith(t, neural.ServerOptions{DrainTimeout: 250 * time.Millisecond}, func(w http.ResponseWriter, r *http.Request) {
+		arrived <- nil
+		if r.URL.Path == "/stuck" {
+			<-release
+		} else {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = io.WriteString(w, "drained")
+	})
+	fetch(t, http.DefaultClient, "http://"+host+"/")
+	<-arrived
+
+	type result struct {
+		body string
+		err  error
+	}
+	request := func(path string) chan result {
+		out := make(chan result, 1)
+		go func() {
+			response, err := http.Get("http://" + host + path)
+			if err != nil {
+				out <- result{err: err}
+				return
+			}
+			defer response.Body.Close()
+			body, err := io.ReadAll(response.Body)
+			out <- result{string(body), err}
+		}()
+		return out
+	}
+	quick, stuck := request("/"), request("/stuck")
+	<-arrived
+	<-arrived
+
+	start := time.Now()
+	if err := cortex.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if r := <-quick; r.err != nil || r.body != "drained" {
+		t.Errorf("expected the in-flight request to finish within the drain, got %q %v", r.body, r.err)
+	}
+	if r := <-stuck; r.err == nil {
+		t.Errorf("expected the stuck request to be cut off once the drain expired, got %q %v", r.body, r.err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the drain to be bounded, took %v", elapsed)
+	}
+}
+
+func Test_Server_Options(t *testing.T) {
+	for name, options := range map[string]neural.ServerOptions{
+		"unpaired":  {CertFile: "cert.pem"},
+		"conflated": {CertFile: "cert.pem", KeyFile: "key.pem", AutoCertHosts: []string{"example.com"}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected invalid options to panic", name)
+				}
+			}()
+			neural.Net.ServerWith(life.Looping, name, ":0", options, func(*std.Impulse) http.Handler { return nil })
+		}()
+	}
+}
+
+func Test_Server_AutoCert(t *testing.T) {
+	cortex, host := serveWith(t, neural.ServerOptions{AutoCertHosts: []string{"example.com"}, AutoCertCache: t.TempDir()})
+	defer cortex.Shutdown()
+
+	// NOTE: Hosts outside of the whitelist are refused without ever reaching out to the certificate authority
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true, ServerName: "elsewhere.test"}}}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := client.Get("https://" + host + "/")
+		if err == nil {
+			t.Fatal("expected the handshake for an unlisted host to be refused")
+		}
+		if strings.Contains(err.Error(), "tls: internal error") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the handshake to be refused, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func Test_Server_CertFailure(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	host := address(t)
+
+	var disconnects atomic.Int32
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	cortex := std.NewCortexWithClock("server", clock)
+	cortex.Frequency = 1
+	defer cortex.Shutdown()
+	err := cortex.Spark(neural.Net.ServerWith(life.Looping, "server", host, neural.ServerOptions{CertFile: certFile, KeyFile: keyFile}, func(*std.Impulse) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	}, func(*std.Impulse) {
+		disconnects.Add(1)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Step(cortex, 5)
+	if count := disconnects.Load(); count != 1 {
+		t.Fatalf("expected a missing certificate to fail only once until it changes, got %d failures", count)
+	}
+
+	certify(t, certFile, keyFile, "late")
+	clock.Step(cortex)
+
+	transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	defer transport.CloseIdleConnections()
+	response, _ := fetch(t, &http.Client{Transport: transport}, "https://"+host+"/")
+	if name := response.TLS.PeerCertificates[0].Subject.CommonName; name != "late" {
+		t.Errorf("expected the server to start once the certificate appeared, got %q", name)
+	}
+}
+
+func Test_Server_Cortices(t *testing.T) {
+	var activations atomic.Int32
+	synapse := neural.Net.Server(life.Looping, "server", "127.0.0.1:0", func(*std.Impulse) http.Handler {
+		activations.Add(1)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	for _, named := range []string{"first", "second"} {
+		clock := std.NewVirtualClock(time.Unix(0, 0))
+		cortex := std.NewCortexWithClock(named, clock)
+		cortex.Frequency = 1
+		defer cortex.Shutdown()
+		if err := cortex.Spark(synapse); err != nil {
+			t.Fatal(err)
+		}
+		clock.Step(cortex)
+	}
+
+	if count := activations.Load(); count != 2 {
+		t.Errorf("expected each cortex to run its own server, got %d activations", count)
+	}
+}
@@ -0,0 +1,192 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/std/neural"
+)
+
+var site = fstest.MapFS{
+	"index.html":      {Data: []byte("<h1>home</h1>"), ModTime: time.Unix(1000, 0)},
+	"docs/index.html": {Data: []byte("<h1>docs</h1>")},
+	"app.js":          {Data: []byte("console.log('app')")},
+	"app.js.gz":       {Data: []byte("gzipped")},
+	"app.js.br":       {Data: []byte("brotli")},
+	"assets/logo.svg": {Data: []byte("<svg/>")},
+	"404.html":        {Data: []byte("<h1>missing</h1>")},
+}
+
+func static(options neural.StaticOptions) http.Handler {
+	return neural.StaticHandler(site, options)(&std.Impulse{})
+}
+
+func request(handler http.Handler, url string, headers ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", url, nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func Test_Static_Index(t *testing.T) {
+	handler := static(neural.StaticOptions{})
+
+	if w := request(handler, "/"); w.Body.String() != "<h1>home</h1>" || w.Header().Get("Last-Modified") == "" {
+		t.Fatalf("expected the root index with its modification time, got %q", w.Body.String())
+	}
+	if w := request(handler, "/docs"); w.Code != http.StatusMovedPermanently || w.Header().Get("Location") != "/docs/" {
+		t.Fatalf("expected a redirect to the directory, got %d", w.Code)
+	}
+	if w := request(handler, "/docs/"); w.Body.String() != "<h1>docs</h1>" {
+		t.Fatalf("expected the directory's index, got %q", w.Body.String())
+	}
+	if w := request(handler, "/assets/"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a directory without an index to 404, got %d", w.Code)
+	}
+}
+
+func Test_Static_ETag(t *testing.T) {
+	handler := static(neural.StaticOptions{})
+
+	w := request(handler, "/app.js")
+	etag := w.Header().Get("ETag")
+	if etag == "" || w.Header().Get("Content-Type") != "text/javascript; charset=utf-8" {
+		t.Fatalf("expected an ETag and content type, got %v", w.Header())
+	}
+	if w = request(handler, "/app.js", "If-None-Match", etag); w.Code != http.StatusNotModified {
+		t.Fatalf("expected a matching ETag to revalidate, got %d", w.Code)
+	}
+}
+
+func Test_Static_Precompressed(t *testing.T) {
+	handler := static(neural.StaticOptions{})
+
+	cases := map[string][2]string{
+		"br, gzip": {"br", "brotli"},
+		"gzip":     {"gzip", "gzipped"},
+		"":         {"", "console.log('app')"},
+	}
+	for accept, expected := range cases {
+		w := request(handler, "/app.js", "Accept-Encoding", accept)
+		if w.Header().Get("Content-Encoding") != expected[0] || w.Body.String() != expected[1] {
+			t.Errorf("%q: expected %v encoding, got %v %q", accept, expected[0], w.Header().Get("Content-Encoding"), w.Body.String())
+		}
+		if w.Header().Get("Content-Type") != "text/javascript; charset=utf-8" {
+			t.Errorf("%q: expected the original content type, got %v", accept, w.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func Test_Static_SPA(t *testing.T) {
+	handler := static(neural.StaticOptions{SPA: true, NotFound: "/404.html"})
+
+	if w := request(handler, "/some/client/route"); w.Code != 200 || w.Body.String() != "<h1>home</h1>" {
+		t.Fatalf("expected client routes to fall back to the index, got %d %q", w.Code, w.Body.String())
+	}
+	if w := request(handler, "/missing.css"); w.Code != http.StatusNotFound || w.Body.String() != "<h1>missing</h1>" {
+		t.Fatalf("expected missing assets to serve the custom 404, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func Test_Static_CacheControl(t *testing.T) {
+	handler := static(neural.StaticOptions{CacheControl: []neural.CacheRule{
+		{Pattern: "*.html", Value: "no-cache"},
+		{Pattern: "assets/*", Value: "public, max-age=31536000, immutable"},
+	}})
+
+	cases := map[string]string{
+		"/":                "no-cache",
+		"/assets/logo.svg": "public, max-age=31536000, immutable",
+		"/app.js":          "",
+	}
+	for url, expected := range cases {
+		if value := request(handler, url).Header().Get("Cache-Control"); value != expected {
+			t.Errorf("%v: expected %q, got %q", url, expected, value)
+		}
+	}
+}
+
+func Test_Static_Dev(t *testing.T) {
+	dir := t.TempDir()
+	page := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(page, []byte("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	handler := neural.StaticHandler(nil, neural.StaticOptions{Dev: dir})(&std.Impulse{})
+
+	w := request(handler, "/")
+	if w.Body.String() != "first" || w.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("expected the page from disk, uncached, got %q", w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+
+	if err := os.WriteFile(page, []byte("second"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		w = request(handler, "/", "If-None-Match", etag)
+		if w.Code == 200 && w.Body.String() == "second" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the edit was never served, got %d %q", w.Code, w.Body.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func Test_Static_ETag_Modified(t *testing.T) {
+	dir := t.TempDir()
+	page := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(page, []byte("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	handler := neural.StaticHandler(os.DirFS(dir), neural.StaticOptions{})(&std.Impulse{})
+	etag := request(handler, "/").Header().Get("ETag")
+
+	// NOTE: Outside of dev mode nothing watches the directory, so the change is only noticed through the file's metadata
+	if err := os.WriteFile(page, []byte("second"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(page, later, later); err != nil {
+		t.Fatal(err)
+	}
+	w := request(handler, "/", "If-None-Match", etag)
+	if w.Code != 200 || w.Body.String() != "second" || w.Header().Get("ETag") == etag {
+		t.Fatalf("expected the changed file to be served with a fresh ETag, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func Test_Static_Dev_Reactivation(t *testing.T) {
+	descriptors := func() int {
+		entries, err := os.ReadDir("/proc/self/fd")
+		if err != nil {
+			t.Skip("open file descriptors can't be counted on this platform")
+		}
+		return len(entries)
+	}
+
+	handlerFn := neural.StaticHandler(nil, neural.StaticOptions{Dev: t.TempDir()})
+	imp := &std.Impulse{}
+	handlerFn(imp)
+	before := descriptors()
+
+	// NOTE: A looping server rebuilds its handler every time it's re-activated
+	for i := 0; i < 10; i++ {
+		handlerFn(imp)
+	}
+	if after := descriptors(); after > before+2 {
+		t.Errorf("expected each re-activation to replace the prior watcher, grew from %d to %d open descriptors", before, after)
+	}
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/std/neural"
+)
+
+var mappings = []neural.VanityMapping{
+	{Prefix: "git.example.net", Remote: "https://github.com/example", Nested: true},
+	{Prefix: "example.com/tools", VCS: "hg", Remote: "https://hg.example.com/tools", Description: "Tooling",
+		Subpackages: map[string]neural.VanityOverride{"lint": {Remote: "https://github.com/example/lint", VCS: "git"}}},
+	{Prefix: "example.com/mirror", VCS: "mod", Remote: "https://proxy.example.com"},
+}
+
+func get(handler http.Handler, url string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+	return w
+}
+
+func Test_Vanity_Meta(t *testing.T) {
+	handler := neural.VanityHandler(mappings...)
+
+	cases := map[string][]string{
+		"http://git.example.net/core/std?go-get=1": {
+			`content="git.example.net/core git https://github.com/example/core.git"`,
+			`content="git.example.net/core https://github.com/example/core https://github.com/example/core/tree/HEAD{/dir}`,
+		},
+		"http://example.com/tools/fmt?go-get=1": {
+			`content="example.com/tools hg https://hg.example.com/tools"`,
+			`https://hg.example.com/tools/file/tip{/dir}`,
+		},
+		"http://example.com/tools/lint/rules?go-get=1": {
+			`content="example.com/tools/lint git https://github.com/example/lint.git"`,
+		},
+		"http://example.com/mirror/pkg?go-get=1": {
+			`content="example.com/mirror mod https://proxy.example.com"`,
+		},
+	}
+	for url, expected := range cases {
+		body := get(handler, url).Body.String()
+		for _, e := range expected {
+			if !strings.Contains(body, e) {
+				t.Errorf("%v: expected %v in\n%v", url, e, body)
+			}
+		}
+	}
+
+	if body := get(handler, "http://example.com/mirror?go-get=1").Body.String(); strings.Contains(body, "go-source") {
+		t.Error("module proxies have no browsable source")
+	}
+	if w := get(handler, "http://unknown.net/pkg?go-get=1"); w.Code != http.StatusNotFound {
+		t.Errorf("expected unmapped imports to 404, got %d", w.Code)
+	}
+}
+
+func Test_Vanity_Redirect(t *testing.T) {
+	handler := neural.VanityHandler(mappings...)
+
+	cases := map[string]string{
+		"http://git.example.net/core":            "https://github.com/example/core",
+		"http://git.example.net/core/std/neural": "https://github.com/example/core/tree/HEAD/std/neural",
+		"http://example.com/tools/fmt":           "https://hg.example.com/tools/file/tip/fmt",
+		"http://example.com/mirror/pkg":          "https://pkg.go.dev/example.com/mirror/pkg",
+	}
+	for url, expected := range cases {
+		w := get(handler, url)
+		if w.Code != http.StatusFound || w.Header().Get("Location") != expected {
+			t.Errorf("%v: expected a redirect to %v, got %d %v", url, expected, w.Code, w.Header().Get("Location"))
+		}
+	}
+}
+
+func Test_Vanity_Index(t *testing.T) {
+	handler := neural.VanityHandler(mappings...)
+
+	body := get(handler, "http://example.com/").Body.String()
+	for _, e := range []string{"example.com/tools", "example.com/tools/lint", "example.com/mirror", "Tooling"} {
+		if !strings.Contains(body, e) {
+			t.Errorf("expected the index to list %v", e)
+		}
+	}
+	if strings.Contains(body, "git.example.net") {
+		t.Error("the index should only list the requested host's modules")
+	}
+}
+
+func Test_Vanity_ImplicitHost(t *testing.T) {
+	handler := neural.VanityHandler(neural.VanityMapping{Remote: "https://github.com/example", Nested: true})
+
+	body := get(handler, "http://anywhere.net:8080/repo?go-get=1").Body.String()
+	if !strings.Contains(body, `content="anywhere.net/repo git https://github.com/example/repo.git"`) {
+		t.Fatalf("expected the request's host to be the prefix, got\n%v", body)
+	}
+}
+
+func Test_Vanity_Root(t *testing.T) {
+	host := address(t)
+	_, port, _ := net.SplitHostPort(host)
+	p, _ := strconv.Atoi(port)
+
+	cortex := std.NewCortex("vanity")
+	cortex.Frequency = 1
+	defer cortex.Shutdown()
+	if err := cortex.Spark(neural.Net.Vanity(life.Looping, "git.example.net", "https://github.com/example", uint(p))); err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: Unlike Vanities, a single vanity server redirects its root to the remote rather than presenting an index
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	response, _ := fetch(t, client, "http://"+host+"/")
+	if response.StatusCode != http.StatusFound || response.Header.Get("Location") != "https://github.com/example" {
+		t.Errorf("expected the root to redirect to the remote, got %d %v", response.StatusCode, response.Header.Get("Location"))
+	}
+}
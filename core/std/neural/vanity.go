@@ -2,14 +2,288 @@ package neural
 
 import (
 	"html/template"
+	"net"
 	"net/http"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"git.ignitelabs.net/janos/core/enum/life"
 	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/atlas"
+	"git.ignitelabs.net/janos/core/sys/rec"
 )
 
+// VanityKey is the atlas key Net.Vanities reads its mappings from.
+const VanityKey = "vanity"
+
+// A VanityMapping directs the Go imports beneath a prefix to the repository which holds them.  In the atlas file, the
+// mappings are listed beneath the VanityKey:
+//
+//	"vanity": [
+//	  { "prefix": "git.ignitelabs.net", "remote": "https://github.com/ignite-laboratories", "nested": true },
+//	  { "prefix": "example.com/tools", "remote": "https://hg.example.com/tools", "vcs": "hg",
+//	    "subpackages": { "lint": { "remote": "https://github.com/example/lint" } } },
+//	  { "prefix": "example.com/mirror", "remote": "https://proxy.example.com", "vcs": "mod" }
+//	]
+type VanityMapping struct {
+	// Prefix is the import path - a host, optionally followed by a path - which the mapping serves.  An empty prefix
+	// serves whichever host the request arrived on.
+	Prefix string `json:"prefix"`
+
+	// VCS is the version control system of the remote - "git" (the default), "hg", or "mod" for a module proxy.
+	VCS string `json:"vcs"`
+
+	// Remote is the repository root - or, if nested, the location holding one repository per path segment.
+	Remote string `json:"remote"`
+
+	// Nested treats every path segment beneath the prefix as its own repository - so "prefix/name" is served by
+	// "remote/name".
+	Nested bool `json:"nested"`
+
+	// Description is shown beside the mapping in the index.
+	Description string `json:"description"`
+
+	// Subpackages maps paths beneath the prefix to repositories of their own.
+	Subpackages map[string]VanityOverride `json:"subpackages"`
+}
+
+// A VanityOverride directs a subpackage of a VanityMapping to its own repository.  An empty VCS inherits the mapping's.
+type VanityOverride struct {
+	VCS    string `json:"vcs"`
+	Remote string `json:"remote"`
+}
+
+// vanityModule is a single resolved import - the repository root, and how to reach it.
+type vanityModule struct {
+	ImportPath string
+	VCS        string
+	Remote     string
+	Rest       string // NOTE: Rest is whatever of the requested path lies beneath the repository root
+}
+
+// Import returns the go-import content for the module.
+func (m vanityModule) Import() string {
+	remote := m.Remote
+	if m.VCS == "git" && !strings.HasSuffix(remote, ".git") {
+		remote += ".git"
+	}
+	return m.ImportPath + " " + m.VCS + " " + remote
+}
+
+// Source returns the go-source content for the module - or nothing, if its remote can't be browsed.
+func (m vanityModule) Source() string {
+	switch m.VCS {
+	case "git":
+		return m.ImportPath + " " + m.Remote + " " + m.Remote + "/tree/HEAD{/dir} " + m.Remote + "/blob/HEAD{/dir}/{file}#L{line}"
+	case "hg":
+		return m.ImportPath + " " + m.Remote + " " + m.Remote + "/file/tip{/dir} " + m.Remote + "/file/tip{/dir}/{file}#L{line}"
+	default:
+		return ""
+	}
+}
+
+// Browse returns where a person visiting the module should be sent.
+func (m vanityModule) Browse() string {
+	switch {
+	case m.VCS == "mod":
+		return "https://pkg.go.dev/" + path.Join(m.ImportPath, m.Rest)
+	case m.Rest == "":
+		return m.Remote
+	case m.VCS == "hg":
+		return m.Remote + "/file/tip/" + m.Rest
+	default:
+		return m.Remote + "/tree/HEAD/" + m.Rest
+	}
+}
+
+// vanities is a set of mappings, ordered so the most specific prefix is consulted first.
+type vanities []VanityMapping
+
+// sanitize drops invalid mappings, defaults the VCS, and orders the mappings by specificity.
+func (v vanities) sanitize(named string) vanities {
+	out := make(vanities, 0, len(v))
+	for _, m := range v {
+		m.Prefix = strings.Trim(m.Prefix, "/")
+		m.Remote = strings.TrimSuffix(m.Remote, "/")
+		if m.VCS == "" {
+			m.VCS = "git"
+		}
+		if m.Remote == "" {
+			rec.Warnf(named, "ignoring vanity mapping '%v' - it has no remote\n", m.Prefix)
+			continue
+		}
+		switch m.VCS {
+		case "git", "hg", "mod":
+		default:
+			rec.Warnf(named, "ignoring vanity mapping '%v' - '%v' isn't a supported VCS\n", m.Prefix, m.VCS)
+			continue
+		}
+		out = append(out, m)
+	}
+	slices.SortStableFunc(out, func(a, b VanityMapping) int {
+		return len(b.Prefix) - len(a.Prefix)
+	})
+	return out
+}
+
+// within reports whether the import path lies at or beneath the prefix, returning whatever lies beneath it.
+func within(importPath string, prefix string) (string, bool) {
+	if importPath == prefix {
+		return "", true
+	}
+	rest, ok := strings.CutPrefix(importPath, prefix+"/")
+	return rest, ok
+}
+
+// resolve finds the module holding the requested import path.
+func (v vanities) resolve(host string, importPath string) (vanityModule, bool) {
+	for _, m := range v {
+		prefix := m.Prefix
+		if prefix == "" {
+			prefix = host
+		}
+		rest, ok := within(importPath, prefix)
+		if !ok {
+			continue
+		}
+
+		// The longest matching subpackage claims the import
+		var claimed string
+		for sub := range m.Subpackages {
+			if _, ok := within(rest, strings.Trim(sub, "/")); ok && len(sub) > len(claimed) {
+				claimed = sub
+			}
+		}
+		if claimed != "" {
+			override := m.Subpackages[claimed]
+			vcs := override.VCS
+			if vcs == "" {
+				vcs = m.VCS
+			}
+			claimed = strings.Trim(claimed, "/")
+			beneath, _ := within(rest, claimed)
+			return vanityModule{ImportPath: prefix + "/" + claimed, VCS: vcs, Remote: strings.TrimSuffix(override.Remote, "/"), Rest: beneath}, true
+		}
+
+		if m.Nested {
+			if rest == "" {
+				return vanityModule{}, false
+			}
+			name, beneath, _ := strings.Cut(rest, "/")
+			return vanityModule{ImportPath: prefix + "/" + name, VCS: m.VCS, Remote: m.Remote + "/" + name, Rest: beneath}, true
+		}
+		return vanityModule{ImportPath: prefix, VCS: m.VCS, Remote: m.Remote, Rest: rest}, true
+	}
+	return vanityModule{}, false
+}
+
+var vanityMeta = template.Must(template.New("meta").Parse(`<!doctype html>
+<html><head>
+<meta name="go-import" content="{{.Import}}">
+{{with .Source}}<meta name="go-source" content="{{.}}">
+{{end}}</head><body>OK</body></html>`))
+
+var vanityIndex = template.Must(template.New("index").Parse(`<!doctype html>
+<html><head>
+<meta charset="utf-8">
+<title>{{.Host}}</title>
+<style>body{font-family:sans-serif;margin:2em auto;max-width:50em}td{padding:.25em 1em .25em 0}code{white-space:nowrap}</style>
+</head><body>
+<h1>{{.Host}}</h1>
+<table>
+{{range .Entries}}<tr><td><code>{{.ImportPath}}</code></td><td>{{.VCS}}</td><td><a href="{{.Remote}}">{{.Remote}}</a></td><td>{{.Description}}</td></tr>
+{{else}}<tr><td>No modules are served from this host.</td></tr>
+{{end}}</table>
+</body></html>`))
+
+type vanityEntry struct {
+	ImportPath  string
+	VCS         string
+	Remote      string
+	Description string
+}
+
+// index lists every module served from the host.
+func (v vanities) index(host string) []vanityEntry {
+	var entries []vanityEntry
+	for _, m := range v {
+		prefix := m.Prefix
+		if prefix == "" {
+			prefix = host
+		}
+		if h, _, _ := strings.Cut(prefix, "/"); h != host {
+			continue
+		}
+
+		root := prefix
+		if m.Nested {
+			root += "/*"
+		}
+		entries = append(entries, vanityEntry{ImportPath: root, VCS: m.VCS, Remote: m.Remote, Description: m.Description})
+		for sub, override := range m.Subpackages {
+			vcs := override.VCS
+			if vcs == "" {
+				vcs = m.VCS
+			}
+			entries = append(entries, vanityEntry{ImportPath: prefix + "/" + strings.Trim(sub, "/"), VCS: vcs, Remote: override.Remote})
+		}
+	}
+	slices.SortFunc(entries, func(a, b vanityEntry) int {
+		return strings.Compare(a.ImportPath, b.ImportPath)
+	})
+	return entries
+}
+
+// VanityHandler serves the provided mappings - answering "go-get" requests with their go-import and go-source meta,
+// redirecting visitors to the source of the module, and presenting an index of the modules at the root of each host.
+func VanityHandler(mappings ...VanityMapping) http.Handler {
+	sanitized := vanities(mappings).sanitize("vanity")
+	return serveVanities(func() vanities {
+		return sanitized
+	})
+}
+
+// serveVanities serves the mappings provided by the loader - consulting it afresh for every request.
+func serveVanities(load func() vanities) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mappings := load()
+		host := strings.ToLower(r.Host)
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		importPath := strings.TrimSuffix(host+r.URL.Path, "/")
+
+		if r.URL.Query().Get("go-get") != "1" && (r.URL.Path == "" || r.URL.Path == "/") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if err := vanityIndex.Execute(w, struct {
+				Host    string
+				Entries []vanityEntry
+			}{host, mappings.index(host)}); err != nil {
+				http.Error(w, "template error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		module, ok := mappings.resolve(host, importPath)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.URL.Query().Get("go-get") == "1" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if err := vanityMeta.Execute(w, module); err != nil {
+				http.Error(w, "template error", http.StatusInternalServerError)
+			}
+			return
+		}
+		http.Redirect(w, r, module.Browse(), http.StatusFound)
+	})
+}
+
 // Vanity drives the git.ignitelabs.net service, which acts as a "vanity URL" for Go imports.
 //
 // NOTE: The address 'git.ignitelabs.net' is implicit through the request and not present in the actual code.
@@ -30,7 +304,8 @@ import (
 //	  <body>OK</body>
 //	</html>
 //
-// That's really it!  No fancy libraries are needed, just a simple HTTP handler =)
+// That's really it!  No fancy libraries are needed, just a simple HTTP handler =)  To serve several hosts, or to
+// configure the mappings through the atlas file, see Vanities.
 func (_net) Vanity(lifecycle life.Cycle, source string, remote string, port uint, onDisconnect ...func(*std.Impulse)) std.Synapse {
 	p := strconv.Itoa(int(port))
 
@@ -85,3 +360,33 @@ func (_net) Vanity(lifecycle life.Cycle, source string, remote string, port uint
 		})
 	}, onDisconnect...)
 }
+
+// Vanities serves the vanity mappings found under the VanityKey of the atlas file - see VanityMapping.  A single
+// server can host any number of domains, and the mappings are reloaded whenever the atlas file changes.  Visiting the
+// root of a host presents an index of the modules it serves, while visiting a module redirects to its source.
+//
+// The provided defaults are served whenever the atlas file provides no mappings - such as when deployed without one.
+func (_net) Vanities(lifecycle life.Cycle, named string, address string, defaults []VanityMapping, onDisconnect ...func(*std.Impulse)) std.Synapse {
+	// NOTE: Each re-activation of the server rebuilds its handler, replacing the prior atlas subscription
+	var refreshing subscription
+
+	return Net.Server(lifecycle, named, address, func(imp *std.Impulse) http.Handler {
+		var current atomic.Pointer[vanities]
+		load := func() {
+			configured := atlas.Parse[vanities](VanityKey)
+			if len(configured) == 0 {
+				configured = defaults
+			}
+			mappings := configured.sanitize(imp.Bridge.String())
+			current.Store(&mappings)
+			rec.Verbosef(imp.Bridge.String(), "loaded %d vanity mappings\n", len(mappings))
+		}
+		load()
+
+		refreshing.replace(imp, atlas.OnRefresh(load))
+
+		return serveVanities(func() vanities {
+			return *current.Load()
+		})
+	}, onDisconnect...)
+}
@@ -0,0 +1,152 @@
+package std
+
+import (
+	"math"
+	"time"
+)
+
+// phaseLockBeats is the number of consecutive in-tolerance beats required before a phase-locked cortex reports as locked.
+const phaseLockBeats = 4
+
+// A PhaseLock places a cortex into phase-locked mode.  Rather than scheduling each timed beat relative to the last one,
+// a phase-locked cortex schedules every beat on a fixed grid of its Frequency - anchored to its own Inception or, when
+// a Leader is provided, to the leader's most recent timed beat.  Because every beat targets an absolute moment on the
+// grid, timer error never accumulates, and beats won't wander over hours of operation.
+//
+// The loop then continuously measures how late each beat lands against its target and feeds that error back through
+// the Gain, learning to wake early enough to cancel out systematic timer latency.  See Cortex.PhaseLocked
+//
+// NOTE: Impulses fire between the grid's beats without shifting it, and a stalled cortex skips any grid moments it missed.
+type PhaseLock struct {
+	// Leader, if set, is the cortex whose beats this cortex locks its phase to - its Frequency may be any multiple of the leader's.
+	Leader *Cortex
+
+	// Offset shifts the locked phase, in radians - for instance, π places every beat halfway between the grid moments.
+	Offset float64
+
+	// Gain defines how aggressively measured phase error is corrected, in the range (0, 1] - defaulting to 0.1.
+	Gain float64
+
+	// Tolerance is the largest absolute phase error, in radians, still considered in lock - defaulting to 5% of a period.
+	Tolerance float64
+}
+
+// PhaseLockStatus reports the state of a phase-locked cortex as of its most recent timed beat.
+type PhaseLockStatus struct {
+	// Engaged indicates the cortex is currently in phase-locked mode.
+	Engaged bool
+
+	// Locked indicates the most recent beats have all landed within the PhaseLock's Tolerance.
+	Locked bool
+
+	// Error is the phase error of the most recent beat, in radians, normalized to [-π, π) - positive values are late.
+	Error float64
+
+	// Offset is the phase error of the most recent beat, as time.
+	Offset time.Duration
+
+	// Lead is how early the cortex currently wakes to cancel out timer latency.
+	Lead time.Duration
+
+	// Beats is the number of consecutive beats which have landed within tolerance.
+	Beats uint
+}
+
+func (lock *PhaseLock) gain() float64 {
+	if lock.Gain <= 0 || lock.Gain > 1 {
+		return 0.1
+	}
+	return lock.Gain
+}
+
+func (lock *PhaseLock) tolerance() float64 {
+	if lock.Tolerance <= 0 {
+		return 2 * math.Pi / 20
+	}
+	return lock.Tolerance
+}
+
+// PhaseLocked returns the phase lock status of the cortex.
+func (ctx *Cortex) PhaseLocked() PhaseLockStatus {
+	ctx.sanityCheck()
+
+	ctx.timeLock.Lock()
+	defer ctx.timeLock.Unlock()
+
+	return ctx.lockStatus
+}
+
+// phaseAnchor returns the moment the phase grid is anchored to.
+func (ctx *Cortex) phaseAnchor(lock *PhaseLock) time.Time {
+	if lock.Leader == nil || lock.Leader == ctx {
+		return ctx.inception
+	}
+
+	lock.Leader.timeLock.Lock()
+	defer lock.Leader.timeLock.Unlock()
+
+	if lock.Leader.timed.IsZero() {
+		return lock.Leader.inception
+	}
+	return lock.Leader.timed
+}
+
+// phaseTarget calculates the next moment on the phase grid after now - and strictly after the previous target.
+func (ctx *Cortex) phaseTarget(lock *PhaseLock, frequency float64, now time.Time, previous time.Time) time.Time {
+	period := _hertzToDuration(frequency)
+	shift := time.Duration(lock.Offset / (2 * math.Pi) * float64(period))
+	anchor := ctx.phaseAnchor(lock).Add(shift)
+
+	elapsed := now.Sub(anchor)
+	k := int64(math.Floor(float64(elapsed)/float64(period))) + 1
+	target := anchor.Add(time.Duration(k) * period)
+	for !target.After(previous) {
+		target = target.Add(period)
+	}
+	return target
+}
+
+// phaseObserve measures the phase error of a beat which landed at the provided moment and returns the corrected lead.
+func (ctx *Cortex) phaseObserve(lock *PhaseLock, frequency float64, target time.Time, moment time.Time, lead time.Duration) time.Duration {
+	period := _hertzToDuration(frequency)
+	offset := moment.Sub(target)
+
+	radians := math.Mod(2*math.Pi*float64(offset)/float64(period)+math.Pi, 2*math.Pi)
+	if radians < 0 {
+		radians += 2 * math.Pi
+	}
+	radians -= math.Pi
+
+	lead += time.Duration(lock.gain() * float64(offset))
+	if lead < 0 {
+		lead = 0
+	}
+	if lead > period/2 {
+		lead = period / 2
+	}
+
+	ctx.timeLock.Lock()
+	defer ctx.timeLock.Unlock()
+
+	status := ctx.lockStatus
+	status.Engaged = true
+	status.Error = radians
+	status.Offset = offset
+	status.Lead = lead
+	if math.Abs(radians) <= lock.tolerance() {
+		status.Beats++
+	} else {
+		status.Beats = 0
+	}
+	status.Locked = status.Beats >= phaseLockBeats
+	ctx.lockStatus = status
+	return lead
+}
+
+// phaseRelease clears the phase lock status once the cortex leaves phase-locked mode.
+func (ctx *Cortex) phaseRelease() {
+	ctx.timeLock.Lock()
+	defer ctx.timeLock.Unlock()
+
+	ctx.lockStatus = PhaseLockStatus{}
+}
@@ -1,34 +1,230 @@
 package std
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"git.ignitelabs.net/janos/core/sys/given/format"
+)
+
+// A Revelation reveals a value from the moment it was last revealed, recording each result into its TemporalBuffer.
+//
+// Revelations can depend upon one another, of any type, forming a dependency graph - see DependsOn.  Revealing a
+// revelation first reveals everything upstream of it, and each revelation is memoized so it's revealed only once no
+// matter how many paths through the graph lead to it.  Without a Cortex, a revelation is memoized for a single call to
+// Reveal - with one, it's memoized for the entire beat of the cortex.  Within the reveal function, the freshly revealed
+// upstream values should be read through Value.
+//
+// For example:
+//
+//	celsius := std.NewRevelation(func(last time.Time) float64 { return sensor.Read() })
+//	fahrenheit := std.NewRevelation(func(last time.Time) float64 { return celsius.Value()*9/5 + 32 })
+//	_ = fahrenheit.DependsOn(celsius)
+//	fahrenheit.Reveal() // reveals celsius, then fahrenheit
+//
+// This is the "cross-referencing" of revelations described in see.Identity, made available to any type.
 type Revelation[T any] struct {
+	Entity
 	*TemporalBuffer[T]
 
+	// Cortex, when set, memoizes this revelation for each beat of the cortex - see Reveal.
+	Cortex *Cortex
+
 	reveal func(last time.Time) T
 	last   time.Time
+	node   *revelationNode
+
+	value    T
+	revealed bool
+	valued   sync.Mutex
+
+	pass  uint64 // NOTE: pass identifies the Reveal call this revelation was last revealed within
+	pulse uint64 // NOTE: pulse identifies the cortex beat this revelation was last revealed within
+	gate  sync.Mutex
 }
 
+// A Dependency is anything a Revelation can depend upon - which is any other Revelation, of any type.
+type Dependency interface {
+	Named() string
+	dependency() *revelationNode
+}
+
+// ErrRevelationCycle is returned when declaring a dependency would cause a revelation to depend upon itself.
+var ErrRevelationCycle = errors.New("revelation dependency cycle")
+
+// revelationNode is a revelation's place within the dependency graph, independent of its type.
+type revelationNode struct {
+	named      func() string
+	refresh    func(pass uint64)
+	upstream   []*revelationNode
+	downstream []*revelationNode
+	stale      atomic.Bool
+}
+
+// revelationGraph guards the edges of every revelation's dependency graph.
+var revelationGraph sync.Mutex
+
+// revelationPasses provides a unique identifier for each call to Reveal.
+var revelationPasses atomic.Uint64
+
 func NewRevelation[T any](reveal func(time.Time) T, window ...*time.Duration) *Revelation[T] {
 	if reveal == nil {
 		panic("reveal function must not be nil")
 	}
 
-	return &Revelation[T]{
-		TemporalBuffer: NewTemporalBuffer[T](window...),
+	buffer := NewTemporalBuffer[T](window...)
+	r := &Revelation[T]{
+		Entity:         NewEntity[format.Default](),
+		TemporalBuffer: buffer,
 		reveal:         reveal,
-		last:           time.Now(),
+		last:           buffer.Clock.Now(),
+	}
+	r.node = &revelationNode{
+		named:   r.Named,
+		refresh: func(pass uint64) { r.revealWithin(pass) },
 	}
+	return r
 }
 
-func (r *Revelation[T]) Reveal() T {
-	if r.reveal == nil {
+func (r *Revelation[T]) sanityCheck() {
+	if r.reveal == nil || r.node == nil {
 		panic("please create a revelation through std.NewRevelation[T]()")
 	}
+	r.TemporalBuffer.sanityCheck()
+}
+
+func (r *Revelation[T]) dependency() *revelationNode {
+	return r.node
+}
+
+// DependsOn declares that this revelation depends upon the provided upstream revelations, which are then revealed
+// before it every time it's revealed.  If any of the dependencies would cause a cycle, none are declared and an
+// ErrRevelationCycle describing the cycle is returned.
+func (r *Revelation[T]) DependsOn(upstream ...Dependency) error {
+	r.sanityCheck()
+
+	revelationGraph.Lock()
+	defer revelationGraph.Unlock()
+
+	for _, up := range upstream {
+		if path := up.dependency().path(r.node); path != nil {
+			names := []string{r.Named()}
+			for _, node := range path {
+				names = append(names, node.named())
+			}
+			return fmt.Errorf("%w: %s", ErrRevelationCycle, strings.Join(names, " → "))
+		}
+	}
+	for _, up := range upstream {
+		node := up.dependency()
+		r.node.upstream = append(r.node.upstream, node)
+		node.downstream = append(node.downstream, r.node)
+	}
+	r.node.stale.Store(true)
+	return nil
+}
+
+// path returns the chain of upstream nodes leading from this node to the target, inclusively - or nil if the target
+// isn't upstream of it.
+func (n *revelationNode) path(target *revelationNode) []*revelationNode {
+	if n == target {
+		return []*revelationNode{n}
+	}
+	for _, up := range n.upstream {
+		if path := up.path(target); path != nil {
+			return append([]*revelationNode{n}, path...)
+		}
+	}
+	return nil
+}
+
+// invalidate marks this node, and everything downstream of it, as needing to be revealed afresh.
+func (n *revelationNode) invalidate(visited map[*revelationNode]struct{}) {
+	if _, ok := visited[n]; ok {
+		return
+	}
+	visited[n] = struct{}{}
+	n.stale.Store(true)
+	for _, down := range n.downstream {
+		down.invalidate(visited)
+	}
+}
+
+// Invalidate pushes an invalidation downstream through the dependency graph, forcing this revelation and everything
+// which depends upon it to be revealed afresh the next time - even within a beat they've already been revealed in.
+// This is useful when the source a revelation reveals from is known to have changed.
+func (r *Revelation[T]) Invalidate() {
+	r.sanityCheck()
+
+	revelationGraph.Lock()
+	defer revelationGraph.Unlock()
+
+	r.node.invalidate(make(map[*revelationNode]struct{}))
+}
+
+// Stale indicates this revelation has been invalidated, or has gained dependencies, since it was last revealed.
+func (r *Revelation[T]) Stale() bool {
+	r.sanityCheck()
+	return r.node.stale.Load()
+}
+
+// Reveal reveals everything upstream of this revelation, then reveals and records its value.  If this revelation has
+// already been revealed within the current beat of its Cortex, and hasn't since been invalidated, the memoized value
+// is returned instead.
+func (r *Revelation[T]) Reveal() T {
+	r.sanityCheck()
+	return r.revealWithin(revelationPasses.Add(1))
+}
+
+// Value returns the most recently revealed value, without revealing it - or the zero value, if it's never been revealed.
+func (r *Revelation[T]) Value() T {
+	r.valued.Lock()
+	defer r.valued.Unlock()
+
+	return r.value
+}
+
+// revealWithin reveals this revelation as part of the provided pass through the dependency graph.
+func (r *Revelation[T]) revealWithin(pass uint64) T {
+	r.gate.Lock()
+	defer r.gate.Unlock()
+
+	var pulse uint64
+	if r.Cortex != nil {
+		pulse = r.Cortex.pulses.Load()
+	}
+
+	r.valued.Lock()
+	revealed := r.revealed
+	r.valued.Unlock()
 
+	if revealed && !r.node.stale.Load() && (r.pass == pass || (r.Cortex != nil && r.pulse == pulse)) {
+		return r.Value()
+	}
+
+	revelationGraph.Lock()
+	upstream := append([]*revelationNode{}, r.node.upstream...)
+	revelationGraph.Unlock()
+
+	for _, up := range upstream {
+		up.refresh(pass)
+	}
+
+	r.node.stale.Store(false)
 	result := r.reveal(r.last)
-	now := time.Now()
+	now := r.Clock.Now()
 	r.Record(now, result)
 	r.last = now
+	r.pass = pass
+	r.pulse = pulse
+
+	r.valued.Lock()
+	r.value = result
+	r.revealed = true
+	r.valued.Unlock()
 	return result
 }
@@ -0,0 +1,113 @@
+package std
+
+import (
+	"fmt"
+	"sync"
+
+	"git.ignitelabs.net/janos/core/sys/shutdown"
+)
+
+// CreateSubCortex creates a new Cortex nested beneath this one, which beats exactly once every divisor beats of its
+// parent.  Rather than running its own timer, a sub-cortex is driven directly by its parent's beats on the parent's
+// clock - so the two can never drift relative to one another.  Sub-cortices can be nested to any depth, and the
+// Bridge of every impulse they fire carries the full path from the root cortex down.
+//
+// Shutting down, muting, or unmuting a cortex propagates down to every sub-cortex beneath it, and the parent drains
+// its sub-cortices during its own shutdown.  Like any cortex, a sub-cortex must be sparked before it beats.
+//
+// NOTE: A sub-cortex's Frequency simply reports the parent's frequency divided down - it has no effect, and neither does
+// a PhaseLock.  If no name is provided, the sub-cortex is named "parent/divisor" - and, if no synaptic limit is
+// provided, it inherits the parent's.
+func (ctx *Cortex) CreateSubCortex(divisor uint, named ...string) *Cortex {
+	ctx.sanityCheck()
+
+	if divisor == 0 {
+		panic("a sub-cortex's divisor must be positive")
+	}
+
+	name := fmt.Sprintf("%s/%d", ctx.Named(), divisor)
+	if len(named) > 0 {
+		name = named[0]
+	}
+
+	child := newCortex(ctx.context, ctx.clock, name, ctx.limit)
+	child.parent = ctx
+	child.divisor = divisor
+	child.divided = make(chan float64, 1)
+	child.Frequency = ctx.Frequency / float64(divisor)
+
+	ctx.master.Lock()
+	ctx.children = append(ctx.children, child)
+	ctx.master.Unlock()
+
+	ctx.OnShutdown(shutdown.Drain, name, func(wg *sync.WaitGroup) {
+		child.master.Lock()
+		sparked := child.sparked
+		child.master.Unlock()
+
+		if sparked {
+			_ = child.Shutdown()
+			<-child.decayed
+		}
+		wg.Done()
+	})
+	return child
+}
+
+// Parent returns the cortex this sub-cortex is nested beneath - or nil, if it's a root cortex.
+func (ctx *Cortex) Parent() *Cortex {
+	ctx.sanityCheck()
+
+	return ctx.parent
+}
+
+// Divisor returns the number of parent beats between each beat of this sub-cortex - or 0, if it's a root cortex.
+func (ctx *Cortex) Divisor() uint {
+	ctx.sanityCheck()
+
+	return ctx.divisor
+}
+
+// Path returns the names of every cortex from the root cortex down to this one.
+func (ctx *Cortex) Path() []string {
+	ctx.sanityCheck()
+
+	var path []string
+	for c := ctx; c != nil; c = c.parent {
+		path = append([]string{c.Named()}, path...)
+	}
+	return path
+}
+
+// subCortices returns a copy of the cortices nested directly beneath this one.
+func (ctx *Cortex) subCortices() []*Cortex {
+	ctx.master.Lock()
+	defer ctx.master.Unlock()
+
+	return append([]*Cortex{}, ctx.children...)
+}
+
+// divide counts a beat of this cortex against each of its sparked sub-cortices, firing a beat into any whose divisor has been reached.
+func (ctx *Cortex) divide() {
+	for _, child := range ctx.subCortices() {
+		child.master.Lock()
+		live := child.sparked && child.alive.Load()
+		child.master.Unlock()
+		if !live {
+			continue
+		}
+
+		child.division++
+		if child.division%child.divisor != 0 {
+			continue
+		}
+		// NOTE: The child is engaged until it has pulsed the divided beat, allowing the tree to Settle deterministically
+		child.engage()
+		select {
+		case child.divided <- ctx.Frequency / float64(child.divisor):
+		default:
+			// The child hasn't yet consumed its last divided beat, so this one coalesces into it
+			child.release()
+		}
+	}
+}
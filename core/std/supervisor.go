@@ -0,0 +1,291 @@
+package std
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"git.ignitelabs.net/janos/core"
+	"git.ignitelabs.net/janos/core/enum/restart"
+	"git.ignitelabs.net/janos/core/sys/atlas"
+	"git.ignitelabs.net/janos/core/sys/rec"
+)
+
+// A Supervisor watches over a set of child synapses and restarts them whenever their neural activity panics.  Which
+// children get restarted is defined by the Strategy, and each restart is delayed by an exponential backoff - starting
+// at Backoff and doubling with every restart in the current Window, up to MaxBackoff.
+//
+// If more than MaxRestarts restarts occur within the Window, the supervisor gives up and escalates.  When the supervisor
+// is itself a child of another supervisor, it escalates by failing as a child of its parent - allowing supervision
+// trees to be built.  Otherwise, it escalates according to Escalation.  For example:
+//
+//	workers := std.NewSupervisor("workers", restart.OneForOne, a, b, c)
+//	root := std.NewSupervisor("root", restart.OneForAll, workers.Synapse(), d)
+//	root.Escalation = restart.Process
+//	cortex.Synapses() <- root.Synapse()
+//
+// NOTE: A supervised child is only considered stopped once its neural goroutine decays, which happens on the next beat
+// of the cortex - a muted cortex will therefore hold restarts until it's unmuted.
+type Supervisor struct {
+	Entity
+
+	// Strategy defines which children are restarted when one panics.
+	Strategy restart.Strategy
+
+	// MaxRestarts defines how many restarts may occur within the Window before the supervisor escalates.
+	MaxRestarts int
+
+	// Window defines the period of time restarts are counted within.
+	Window time.Duration
+
+	// Backoff defines the delay before the first restart within the Window.
+	Backoff time.Duration
+
+	// MaxBackoff caps the exponential backoff between restarts.
+	MaxBackoff time.Duration
+
+	// Escalation defines what happens when a root supervisor exhausts its restart budget.
+	Escalation restart.Escalation
+
+	// ExitCode is provided to core.ShutdownNow when escalating with restart.Process.
+	ExitCode int
+
+	children []Synapse
+}
+
+// NewSupervisor creates a named Supervisor over the provided child synapses.  By default, it allows 3 restarts within
+// 5 seconds - backing off from 100ms up to 30s - before shutting down the cortex it's wired into with an exit code of 1.
+func NewSupervisor(named string, strategy restart.Strategy, children ...Synapse) *Supervisor {
+	return &Supervisor{
+		Entity:      NewEntityNamed(named),
+		Strategy:    strategy,
+		MaxRestarts: 3,
+		Window:      5 * time.Second,
+		Backoff:     100 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		ExitCode:    1,
+		children:    children,
+	}
+}
+
+// Synapse creates a Synapse which sparks every child under supervision.  The supervision lasts until the cortex shuts
+// down or, for a nested supervisor, until its parent stops it.
+func (s *Supervisor) Synapse() Synapse {
+	rec.Verbosef(core.ModuleName, "%v is creating supervisor '%s'\n", core.Name.Name, s.Named())
+	return func(imp *Impulse) {
+		imp.Bridge = append((*imp.Cortex).Path(), s.Named())
+
+		sv := &supervision{
+			Supervisor: s,
+			impulse:    imp,
+			parent:     imp.supervised,
+			children:   make([]*supervised, len(s.children)),
+			failures:   make(chan *supervised, len(s.children)+1),
+		}
+		(*imp.Cortex).track(imp)
+		for i := range s.children {
+			sv.start(i)
+		}
+		go sv.watch()
+	}
+}
+
+// supervision is a single sparked instance of a Supervisor.
+type supervision struct {
+	*Supervisor
+
+	impulse  *Impulse
+	parent   *supervised
+	children []*supervised
+	failures chan *supervised
+	restarts []time.Time
+	gave     bool
+}
+
+// supervised is a single incarnation of a supervised child.
+type supervised struct {
+	supervision *supervision
+	index       int
+	impulse     *Impulse
+	done        chan any
+
+	failure sync.Once
+	exit    sync.Once
+}
+
+// fail reports the child's failure to its supervisor, at most once per incarnation.
+func (c *supervised) fail(reason any) {
+	c.failure.Do(func() {
+		rec.Warnf(c.supervision.impulse.Bridge.String(), "child '%s' failed: %v\n", c.impulse.Bridge.String(), reason)
+
+		// NOTE: Failures are reported from within the failing neural goroutine, which must never block on its supervisor
+		go func() {
+			select {
+			case c.supervision.failures <- c:
+			case <-c.supervision.impulse.Context().Done():
+			}
+		}()
+	})
+}
+
+// exited signals that the child's neural goroutine has decayed.
+func (c *supervised) exited() {
+	c.exit.Do(func() {
+		close(c.done)
+	})
+}
+
+// start sparks a new incarnation of the child at the provided index.
+func (sv *supervision) start(index int) {
+	imp := sv.impulse.Cortex.spawnWithin(sv.impulse.Context())
+
+	child := &supervised{
+		supervision: sv,
+		index:       index,
+		impulse:     imp,
+		done:        make(chan any),
+	}
+	imp.supervised = child
+	sv.children[index] = child
+	sv.Supervisor.children[index](imp)
+}
+
+// stop cancels the provided children and waits for their neural goroutines to decay - for up to atlas.ShutdownTimeout,
+// after which any children which are still stuck are reported by their Bridge name and abandoned.
+func (sv *supervision) stop(children ...*supervised) {
+	for _, child := range children {
+		child.impulse.cancel()
+	}
+
+	var expired <-chan time.Time
+	if atlas.ShutdownTimeout > 0 {
+		timer := time.NewTimer(atlas.ShutdownTimeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for i, child := range children {
+		select {
+		case <-child.done:
+			continue
+		case <-expired:
+		}
+
+		// NOTE: Once the timeout has passed, only report the stragglers
+		var stuck []string
+		for _, child := range children[i:] {
+			select {
+			case <-child.done:
+			default:
+				stuck = append(stuck, child.impulse.Bridge.String())
+			}
+		}
+		rec.Errorf(sv.impulse.Bridge.String(), "timed out after %v stopping: %s\n", atlas.ShutdownTimeout, strings.Join(stuck, ", "))
+		return
+	}
+}
+
+// watch handles child failures until the supervision's impulse decays.
+func (sv *supervision) watch() {
+	ctx := sv.impulse.Context()
+	clock := sv.impulse.Clock()
+
+	defer func() {
+		sv.stop(sv.children...)
+		rec.Verbosef(sv.impulse.Bridge.String(), "decayed\n")
+		sv.impulse.decay()
+	}()
+
+	for {
+		var failed *supervised
+		select {
+		case <-ctx.Done():
+			return
+		case failed = <-sv.failures:
+		}
+
+		// NOTE: Failures from a stale incarnation (or after giving up) have already been handled
+		if sv.gave || sv.children[failed.index] != failed {
+			continue
+		}
+
+		now := clock.Now()
+		sv.restarts = append(sv.restarts, now)
+		var trim int
+		for _, moment := range sv.restarts {
+			if now.Sub(moment) >= sv.Window {
+				trim++
+			} else {
+				break
+			}
+		}
+		sv.restarts = sv.restarts[trim:]
+
+		if len(sv.restarts) > sv.MaxRestarts {
+			sv.gave = true
+			sv.stop(sv.children...)
+			sv.escalate()
+			continue
+		}
+
+		indices := []int{failed.index}
+		if sv.Strategy == restart.OneForAll {
+			indices = make([]int, len(sv.children))
+			for i := range sv.children {
+				indices[i] = i
+			}
+		}
+
+		targets := make([]*supervised, len(indices))
+		for i, index := range indices {
+			targets[i] = sv.children[index]
+		}
+		sv.stop(targets...)
+
+		delay := sv.backoff(len(sv.restarts))
+		rec.Verbosef(sv.impulse.Bridge.String(), "restarting %d child(ren) in %v\n", len(indices), delay)
+		if delay > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-clock.After(delay):
+			}
+		}
+
+		for _, index := range indices {
+			sv.start(index)
+		}
+	}
+}
+
+// backoff calculates the delay before the nth restart within the window.
+func (sv *supervision) backoff(n int) time.Duration {
+	delay := sv.Backoff
+	for i := 1; i < n && delay < sv.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if sv.MaxBackoff > 0 && delay > sv.MaxBackoff {
+		delay = sv.MaxBackoff
+	}
+	return delay
+}
+
+// escalate hands the failure up the supervision tree, or to the cortex or process once at the root.
+func (sv *supervision) escalate() {
+	bridge := sv.impulse.Bridge.String()
+
+	if sv.parent != nil {
+		rec.Errorf(bridge, "exceeded %d restarts in %v - escalating to parent supervisor\n", sv.MaxRestarts, sv.Window)
+		sv.parent.fail("restart budget exhausted")
+		return
+	}
+
+	switch sv.Escalation {
+	case restart.Process:
+		rec.Errorf(bridge, "exceeded %d restarts in %v - shutting down\n", sv.MaxRestarts, sv.Window)
+		go core.ShutdownNow(sv.ExitCode)
+	default:
+		rec.Errorf(bridge, "exceeded %d restarts in %v - shutting down cortex\n", sv.MaxRestarts, sv.Window)
+		_ = (*sv.impulse.Cortex).Shutdown()
+	}
+}
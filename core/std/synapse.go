@@ -1,8 +1,6 @@
 package std
 
 import (
-	"time"
-
 	"git.ignitelabs.net/janos/core"
 	"git.ignitelabs.net/janos/core/enum/life"
 	"git.ignitelabs.net/janos/core/sys/id"
@@ -25,98 +23,101 @@ func NewSynapseFromNeural(lifeycle life.Cycle, neuron Neural) Synapse {
 	rec.Verbosef(core.ModuleName, "%v is creating synapse '%s'\n", core.Name.Name, neuron.Named())
 	count := uint(0)
 	return func(imp *Impulse) {
-		creation := time.Now()
-		imp.Bridge = []string{(*imp.Cortex).Named(), neuron.Named()}
+		clock := (*imp.Cortex).clock
+		creation := clock.Now()
+		imp.Bridge = append((*imp.Cortex).Path(), neuron.Named())
 		imp.Neuron = neuron
+		imp.lifecycle = lifeycle
 
 		rec.Verbosef((*imp.Cortex).Named(), "wiring axon to neural endpoint '%s'\n", neuron.Named())
 
 		panicSafeAction := func(i *Impulse) {
 			defer func() {
 				if r := recover(); r != nil {
-					rec.Printf(i.Bridge.String(), "neural panic: %s\n", r)
+					rec.Errorf(i.Bridge.String(), "neural panic: %s\n", r)
+					if metrics := (*i.Cortex).metrics.Load(); metrics != nil {
+						metrics.panicked(i)
+					}
+					i.fail(r)
 				}
 			}()
 
 			neuron.Action(i)
 		}
 
+		// NOTE: Every neural goroutine engages the cortex before launching, allowing the cortex to Settle deterministically,
+		// and is tracked until it decays, allowing the cortex to drain it during shutdown
+		(*imp.Cortex).engage()
+		(*imp.Cortex).track(imp)
+
 		switch lifeycle {
 		case life.Looping:
 			// 0 - Looping activations cyclically reactivate the same goroutine when the last finishes and the potential is high
 			go func() {
 				rec.Verbosef(imp.Bridge.String(), "looping\n")
-				for (*imp.Cortex).Alive() {
+				for imp.alive() {
 					event := &SynapticEvent{
 						id:              id.Next(),
 						SynapseCreation: creation,
-						Inception:       time.Now(),
+						Inception:       clock.Now(),
 					}
 					imp.currentEvent = event
 					imp.Count = count
-					imp.Beat = (*imp.Cortex).beat
+					imp.Beat = uint((*imp.Cortex).beat.Load())
 					imp.BeatPeriod = (*imp.Cortex).BeatPeriod
-					if neuron.Potential(imp) && !imp.Mute && (*imp.Cortex).Alive() {
-						event.Activation = time.Now()
-						imp.Timeline.Add(*event)
+					if neuron.Potential(imp) && !imp.Mute && imp.alive() {
+						imp.activate(event, clock.Now())
 						panicSafeAction(imp)
-						imp.Timeline.setCompleted(event.id, time.Now())
+						imp.complete(event, clock.Now())
 						count++
 					}
 
-					if !imp.Decay && (*imp.Cortex).Alive() {
-						(*imp.Cortex).master.Lock()
-						(*imp.Cortex).clock.Wait()
-						(*imp.Cortex).master.Unlock()
+					if !imp.Decay && imp.alive() {
+						(*imp.Cortex).await()
 					} else {
 						break
 					}
 				}
-				(*imp.Cortex).hold.Add(1)
-				if neuron.Cleanup != nil {
-					neuron.Cleanup(imp)
-				}
+				neuron.Cleanup(imp)
+				imp.decay()
 				rec.Verbosef(imp.Bridge.String(), "decayed\n")
-				(*imp.Cortex).hold.Done()
+				(*imp.Cortex).release()
 			}()
 		case life.Stimulative:
 			// 1 - Stimulative activations launch new goroutines on every impulse the potential is high
 			go func() {
 				rec.Verbosef(imp.Bridge.String(), "stimulating\n")
-				for (*imp.Cortex).Alive() {
+				for imp.alive() {
 					event := &SynapticEvent{
 						id:              id.Next(),
 						SynapseCreation: creation,
-						Inception:       time.Now(),
+						Inception:       clock.Now(),
 					}
 					imp.currentEvent = event
 					imp.Count = count
-					imp.Beat = (*imp.Cortex).beat
+					imp.Beat = uint((*imp.Cortex).beat.Load())
 					imp.BeatPeriod = (*imp.Cortex).BeatPeriod
-					if neuron.Potential(imp) && !imp.Mute && (*imp.Cortex).Alive() {
-						event.Activation = time.Now()
-						imp.Timeline.Add(*event)
+					if neuron.Potential(imp) && !imp.Mute && imp.alive() {
+						imp.activate(event, clock.Now())
+						(*imp.Cortex).engage()
 						go func() {
+							defer (*imp.Cortex).release()
 							panicSafeAction(imp)
-							imp.Timeline.setCompleted(event.id, time.Now())
+							imp.complete(event, clock.Now())
 						}()
 						count++
 					}
 
-					if !imp.Decay && (*imp.Cortex).Alive() {
-						(*imp.Cortex).master.Lock()
-						(*imp.Cortex).clock.Wait()
-						(*imp.Cortex).master.Unlock()
+					if !imp.Decay && imp.alive() {
+						(*imp.Cortex).await()
 					} else {
 						break
 					}
 				}
-				(*imp.Cortex).hold.Add(1)
-				if neuron.Cleanup != nil {
-					neuron.Cleanup(imp)
-				}
+				neuron.Cleanup(imp)
+				imp.decay()
 				rec.Verbosef(imp.Bridge.String(), "decayed\n")
-				(*imp.Cortex).hold.Done()
+				(*imp.Cortex).release()
 			}()
 		case life.Triggered:
 			// 2 - Triggered activations are a one-shot GUARANTEE once the potential goes high
@@ -125,30 +126,25 @@ func NewSynapseFromNeural(lifeycle life.Cycle, neuron Neural) Synapse {
 				event := &SynapticEvent{
 					id:              id.Next(),
 					SynapseCreation: creation,
-					Inception:       time.Now(),
+					Inception:       clock.Now(),
 				}
 				imp.currentEvent = event
-				for (*imp.Cortex).Alive() && !neuron.Potential(imp) {
-					(*imp.Cortex).master.Lock()
-					(*imp.Cortex).clock.Wait()
-					(*imp.Cortex).master.Unlock()
+				for imp.alive() && !neuron.Potential(imp) {
+					(*imp.Cortex).await()
 				}
-				if (*imp.Cortex).Alive() && !imp.Mute {
+				if imp.alive() && !imp.Mute {
 					imp.Count = count
-					imp.Beat = (*imp.Cortex).beat
+					imp.Beat = uint((*imp.Cortex).beat.Load())
 					imp.BeatPeriod = (*imp.Cortex).BeatPeriod
-					event.Activation = time.Now()
-					imp.Timeline.Add(*event)
+					imp.activate(event, clock.Now())
 					panicSafeAction(imp)
-					imp.Timeline.setCompleted(event.id, time.Now())
+					imp.complete(event, clock.Now())
 					count++
 				}
-				(*imp.Cortex).hold.Add(1)
-				if neuron.Cleanup != nil {
-					neuron.Cleanup(imp)
-				}
+				neuron.Cleanup(imp)
+				imp.decay()
 				rec.Verbosef(imp.Bridge.String(), "decayed\n")
-				(*imp.Cortex).hold.Done()
+				(*imp.Cortex).release()
 			}()
 		case life.Impulse:
 			// 3 - Impulse activations are a one-shot ATTEMPT regardless of potential
@@ -157,25 +153,22 @@ func NewSynapseFromNeural(lifeycle life.Cycle, neuron Neural) Synapse {
 				event := &SynapticEvent{
 					id:              id.Next(),
 					SynapseCreation: creation,
-					Inception:       time.Now(),
+					Inception:       clock.Now(),
 				}
 				imp.currentEvent = event
-				if (*imp.Cortex).Alive() && neuron.Potential(imp) && !imp.Mute {
+				if imp.alive() && neuron.Potential(imp) && !imp.Mute {
 					imp.Count = count
-					imp.Beat = (*imp.Cortex).beat
+					imp.Beat = uint((*imp.Cortex).beat.Load())
 					imp.BeatPeriod = (*imp.Cortex).BeatPeriod
-					event.Activation = time.Now()
-					imp.Timeline.Add(*event)
+					imp.activate(event, clock.Now())
 					panicSafeAction(imp)
-					imp.Timeline.setCompleted(event.id, time.Now())
+					imp.complete(event, clock.Now())
 					count++
 				}
-				(*imp.Cortex).hold.Add(1)
-				if neuron.Cleanup != nil {
-					neuron.Cleanup(imp)
-				}
+				neuron.Cleanup(imp)
+				imp.decay()
 				rec.Verbosef(imp.Bridge.String(), "decayed\n")
-				(*imp.Cortex).hold.Done()
+				(*imp.Cortex).release()
 			}()
 		}
 	}
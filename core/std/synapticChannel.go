@@ -0,0 +1,97 @@
+package std
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"git.ignitelabs.net/janos/core/enum/overflow"
+)
+
+// Overflow defines how each of a cortex's synaptic channels behaves once it holds atlas.SynapticChannelLimit signals.
+// Policies are read live on every send, and the zero value blocks on every channel.
+type Overflow struct {
+	// Synapses applies to the synapses provided to Spark.
+	//
+	// NOTE: Sending directly into the Synapses channel always blocks once it's full.
+	Synapses overflow.Policy
+
+	// Impulses applies to Impulse requests.
+	Impulses overflow.Policy
+
+	// Mutes applies to both Mute and Unmute requests.
+	Mutes overflow.Policy
+}
+
+// Dropped holds the number of signals each of a cortex's synaptic channels has dropped (or coalesced) due to overflow.
+type Dropped struct {
+	Synapses uint64
+	Impulses uint64
+	Mutes    uint64
+}
+
+// synapticChannel is a buffered channel which applies an overflow.Policy to every send.
+type synapticChannel[T any] struct {
+	channel chan T
+	dropped atomic.Uint64
+	gate    sync.Mutex
+}
+
+func newSynapticChannel[T any](limit int) *synapticChannel[T] {
+	return &synapticChannel[T]{
+		channel: make(chan T, limit),
+	}
+}
+
+// send delivers the signal according to the provided policy, returning whether it was delivered.
+func (s *synapticChannel[T]) send(signal T, policy overflow.Policy) bool {
+	switch policy {
+	case overflow.DropNewest:
+		select {
+		case s.channel <- signal:
+			return true
+		default:
+			s.dropped.Add(1)
+			return false
+		}
+	case overflow.DropOldest:
+		s.gate.Lock()
+		defer s.gate.Unlock()
+
+		for {
+			select {
+			case s.channel <- signal:
+				return true
+			default:
+			}
+
+			select {
+			case <-s.channel:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	case overflow.Coalesce:
+		s.gate.Lock()
+		defer s.gate.Unlock()
+
+		if len(s.channel) > 0 {
+			s.dropped.Add(1)
+			return false
+		}
+		select {
+		case s.channel <- signal:
+			return true
+		default:
+			s.dropped.Add(1)
+			return false
+		}
+	default:
+		s.channel <- signal
+		return true
+	}
+}
+
+// Dropped returns the number of signals this channel has dropped (or coalesced).
+func (s *synapticChannel[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
@@ -5,6 +5,10 @@ import "time"
 type SynapticEvent struct {
 	id uint64
 
+	beat       uint
+	beatPeriod int
+	count      uint
+
 	// SynapseCreation represents the moment the synaptic connection was created.
 	SynapseCreation time.Time
 
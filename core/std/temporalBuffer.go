@@ -1,11 +1,12 @@
 package std
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
+	"git.ignitelabs.net/janos/core/enum/integration"
 	"git.ignitelabs.net/janos/core/sys/atlas"
-	"git.ignitelabs.net/janos/core/sys/num/tiny"
 )
 
 // A TemporalBuffer is a type of buffer that holds data for a period of time, rather than up to a fixed size.
@@ -20,24 +21,35 @@ import (
 // 2 - You are not required to add elements temporally sequentially - instead, you provide the moment to add
 //
 // 3 - Temporal buffers automatically trim on access
+//
+// 4 - Temporal buffers can be persisted to disk, surviving a restart - see Persist
 type TemporalBuffer[T any] struct {
-	buffer []instant[T]
+	buffer temporalStore[T]
 
 	Window *time.Duration
 
-	master sync.Mutex
+	// Clock is the clock the buffer trims against - if nil, the SystemClock is implied.
+	Clock Clock
+
+	storage *temporalStorage[T]
+	ring    bool     // NOTE: ring marks a buffer which is trimmed in the background, rather than on access - see NewTemporalRing
+	trimmer chan any // NOTE: trimmer is closed to stop the background trimmer of a ring buffer
+	master  sync.RWMutex
 }
 
 // NewTemporalBuffer creates a new instance of a temporal buffer which observes the provided window of time.  If no
 // window is provided, this will default to atlas.ObservanceWindow
+//
+// NOTE: For high-frequency recording, see NewTemporalRing
 func NewTemporalBuffer[T any](window ...*time.Duration) *TemporalBuffer[T] {
 	w := &atlas.ObservanceWindow
 	if len(window) > 0 {
 		w = window[0]
 	}
 	return &TemporalBuffer[T]{
-		buffer: make([]instant[T], 0),
+		buffer: &temporalSlice[T]{},
 		Window: w,
+		Clock:  SystemClock,
 	}
 }
 
@@ -46,6 +58,23 @@ type instant[T any] struct {
 	Element T
 }
 
+// temporalStore holds a buffer's instants in temporal order.
+type temporalStore[T any] interface {
+	// insert places the element into the store in temporal order, before any elements of the same moment.
+	insert(moment time.Time, element T)
+
+	// trim removes every element at or before the cutoff, while retaining at least the minimum number of elements.
+	trim(cutoff time.Time, minimum int)
+
+	// yield returns a copy of the newest elements, up to a positive depth, which trim would retain.
+	yield(cutoff time.Time, minimum int, depth int) []instant[T]
+
+	// update calls fn with each element from newest to oldest until it returns true.
+	update(fn func(*instant[T]) bool)
+
+	len() int
+}
+
 func (b *TemporalBuffer[T]) sanityCheck() {
 	if b.buffer == nil {
 		panic("temporal buffer set to nil - please create these through std.NewTemporalBuffer")
@@ -53,33 +82,26 @@ func (b *TemporalBuffer[T]) sanityCheck() {
 	if b.Window == nil {
 		b.Window = &atlas.ObservanceWindow
 	}
+	if b.Clock == nil {
+		b.Clock = SystemClock
+	}
+}
+
+// cutoff returns the moment at or before which elements fall outside of the observance window.
+func (b *TemporalBuffer[T]) cutoff() time.Time {
+	return b.Clock.Now().Add(-*b.Window)
 }
 
 func (b *TemporalBuffer[T]) trim() {
 	b.sanityCheck()
-	now := time.Now()
-	cutoff := now.Add(-*b.Window)
-
-	var i int
-	for _, inst := range b.buffer {
-		if inst.Moment.After(cutoff) {
-			break
-		}
-		i++
-	}
-	maximum := len(b.buffer) - int(atlas.ObservedMinimum)
-	if maximum < 0 {
-		maximum = 0
-	}
-	if i > maximum {
-		i = maximum
-	}
-
-	b.buffer = b.buffer[i:]
+	b.buffer.trim(b.cutoff(), int(atlas.ObservedMinimum))
 }
 
 func (b *TemporalBuffer[T]) Len() uint {
-	return uint(len(b.buffer))
+	b.master.RLock()
+	defer b.master.RUnlock()
+
+	return uint(b.buffer.len())
 }
 
 // LatestSince will grab the latest elements after the provided moment in time, exclusively.  If you'd like to include the
@@ -118,63 +140,74 @@ func (b *TemporalBuffer[T]) Latest(depth ...int) []instant[T] {
 	}
 
 	b.sanityCheck()
-	out := b.Yield()
-	if d <= 0 {
-		return out
-	}
-	end := len(out) - d
-	if end < 0 {
-		end = 0
-	}
-	out = out[end:]
-	if len(out) > d {
-		panic("too many elements")
-	}
-	return out
+	return b.yield(d)
 }
 
 func (b *TemporalBuffer[T]) Yield() []instant[T] {
+	b.sanityCheck()
+	return b.yield(-1)
+}
+
+// yield copies out the most recent elements up to the provided depth - or every element, if the depth isn't positive.
+func (b *TemporalBuffer[T]) yield(depth int) []instant[T] {
+	// NOTE: A ring buffer is trimmed in the background, so its readers only ever share the lock
+	b.master.RLock()
+	if b.ring {
+		defer b.master.RUnlock()
+	} else {
+		b.master.RUnlock()
+		b.master.Lock()
+		defer b.master.Unlock()
+
+		b.trim()
+	}
+
+	return b.buffer.yield(b.cutoff(), int(atlas.ObservedMinimum), depth)
+}
+
+func (b *TemporalBuffer[T]) Record(moment time.Time, element T) {
 	b.sanityCheck()
 	b.master.Lock()
 	defer b.master.Unlock()
 
-	b.trim()
-	out := make([]instant[T], len(b.buffer))
-	copy(out, b.buffer)
-	return out
+	b.buffer.insert(moment, element)
+	b.persist(moment, element)
+	if !b.ring {
+		b.trim()
+	} else if b.trimmer == nil {
+		b.trimmer = make(chan any)
+		go b.trimRing(b.trimmer, b.Clock)
+	}
 }
 
-func (b *TemporalBuffer[T]) Record(moment time.Time, element T) {
+// Close stops the buffer's background trimmer and closes its on-disk log, if it has either - the buffer then
+// continues in memory, trimming on access.
+func (b *TemporalBuffer[T]) Close() error {
 	b.sanityCheck()
 	b.master.Lock()
 	defer b.master.Unlock()
 
-	t := len(b.buffer) - 1
-	for i := len(b.buffer) - 1; i >= 0; i-- {
-		if b.buffer[i].Moment.Before(moment) {
-			break
-		}
-		t--
+	b.ring = false
+	if b.trimmer != nil {
+		close(b.trimmer)
+		b.trimmer = nil
 	}
-	t++
-	if t < 0 {
-		t = 0
+	if b.storage == nil {
+		return nil
 	}
-	left := b.buffer[:t]
-	right := b.buffer[t:]
-	left = append(left, instant[T]{moment, element})
-	left = append(left, right...)
-	b.buffer = left
-
-	b.trim()
+	err := b.storage.log.Close()
+	b.storage = nil
+	untrack(b)
+	return err
 }
 
 // Calculate will yield the Latest(depth) - then, for each of the elements from oldest to newest, call calcFn(dt, element)
-// before returning the accumulated results.
+// before returning the accumulated results.  The dt is the duration since the previous element.
+//
+// NOTE: The oldest element has no predecessor, so it's provided a negative dt.
 func (b *TemporalBuffer[T]) Calculate(depth int, calcFn func(time.Duration, T) any) []instant[any] {
 	b.sanityCheck()
-	//yield := b.Latest(depth)
-	return nil
+	return calculate(b.Latest(depth), calcFn)
 }
 
 // CalculateSince will yield the LatestSince(moment, includeMoment) - then, for each of the elements from oldest to newest, call
@@ -183,53 +216,220 @@ func (b *TemporalBuffer[T]) Calculate(depth int, calcFn func(time.Duration, T) a
 // NOTE: For integration and differentiation, you'll want to include the provided moment in the calculation to create a continuous calculation =)
 func (b *TemporalBuffer[T]) CalculateSince(moment time.Time, calcFn func(time.Duration, T) any, includeMoment ...bool) []instant[any] {
 	b.sanityCheck()
-	//yield := b.LatestSince(moment)
-	return nil
+	return calculate(b.LatestSince(moment, includeMoment...), calcFn)
+}
+
+func calculate[T any](yield []instant[T], calcFn func(time.Duration, T) any) []instant[any] {
+	out := make([]instant[any], len(yield))
+	for i, inst := range yield {
+		dt := time.Duration(-1)
+		if i > 0 {
+			dt = inst.Moment.Sub(yield[i-1].Moment)
+		}
+		out[i] = instant[any]{inst.Moment, calcFn(dt, inst.Element)}
+	}
+	return out
 }
 
-// Integrate will perform standard temporal integration against the provided depth of elements.  This will yield the area
-// between each moment and the total calculated area.  If you'd like to implement your own integration logic,
-// please leverage Calculate.  To integrate to custom precision, please use IntegrateTolerance.
+// Integrate integrates the provided depth of elements over time (in seconds) using the provided rule.  This will yield
+// the area of the interval leading up to each moment - the oldest moment's area always being zero - and the total
+// area.  If you'd like to implement your own integration logic, please leverage Calculate.
 //
 // NOTE: If the elements are NOT implicitly parseable, this will panic.  In that case, please provide a 'parseFn'
-// which translates the buffered information into a parseable type.  A parseable type is any numeric, string, or function provider type.
-func (b *TemporalBuffer[T]) Integrate(base uint16, depth int, parseFn ...func(T) any) ([]instant[any], float64) {
-	return b.IntegrateTolerance(base, depth, atlas.Precision, parseFn...)
-}
-func (b *TemporalBuffer[T]) IntegrateTolerance(base uint16, depth int, precision uint, parseFn ...func(T) any) ([]instant[any], float64) {
+// which translates the buffered information into a float64.  Any numeric type is implicitly parseable.
+func (b *TemporalBuffer[T]) Integrate(rule integration.Rule, depth int, parseFn ...func(T) float64) ([]instant[float64], float64) {
 	b.sanityCheck()
-	area := 0.0
-	last := 0.0
-	return b.Calculate(depth, func(dt time.Duration, element T) any {
-		var number any
-		if len(parseFn) > 0 {
-			number = parseFn[0](element)
-		} else {
-			number = element
-		}
-		if dt < 0 {
-			last = 0.0
-			return 0.0
-		}
-		last = tiny.ParseInto[float64](tiny.Multiply(dt, tiny.Add(number, last), 0.5))
-		area = tiny.ParseInto[float64](tiny.Add(area, last))
-		return last
-	}), area
+	return integrate(rule, parse(b.Latest(depth), parseFn...))
 }
 
-func (b *TemporalBuffer[T]) IntegrateSince(moment time.Time, parseFn ...func(T) any) []instant[T] {
+// IntegrateSince integrates every element since the provided moment over time (in seconds) using the provided rule.
+//
+// NOTE: This is INCLUSIVE of the provided moment =)
+//
+// See Integrate
+func (b *TemporalBuffer[T]) IntegrateSince(rule integration.Rule, moment time.Time, parseFn ...func(T) float64) ([]instant[float64], float64) {
 	b.sanityCheck()
-	// NOTE: This is INCLUSIVE of the provided moment =)
-	return nil
+	return integrate(rule, parse(b.LatestSince(moment, true), parseFn...))
 }
 
-func (b *TemporalBuffer[T]) Differentiate(depth int, parseFn ...func(T) any) []instant[T] {
+// Differentiate calculates the first or second order derivative (per second) at each of the provided depth of elements.
+// The derivatives are calculated from the parabola through each moment and its neighbors, so irregularly spaced
+// moments are handled exactly for quadratic signals.  A first order derivative requires at least two elements, while
+// a second order derivative requires at least three - otherwise, no results are yielded.  Any other order also yields
+// no results.
+//
+// NOTE: If the elements are NOT implicitly parseable, this will panic.  In that case, please provide a 'parseFn'
+// which translates the buffered information into a float64.  Any numeric type is implicitly parseable.
+func (b *TemporalBuffer[T]) Differentiate(order uint, depth int, parseFn ...func(T) float64) []instant[float64] {
 	b.sanityCheck()
-	return nil
+	return differentiate(order, parse(b.Latest(depth), parseFn...))
 }
 
-func (b *TemporalBuffer[T]) DifferentiateSince(moment time.Time, parseFn ...func(T) any) []instant[T] {
+// DifferentiateSince calculates the first or second order derivative (per second) at every element since the provided moment.
+//
+// NOTE: This is INCLUSIVE of the provided moment =)
+//
+// See Differentiate
+func (b *TemporalBuffer[T]) DifferentiateSince(order uint, moment time.Time, parseFn ...func(T) float64) []instant[float64] {
 	b.sanityCheck()
-	// NOTE: This is INCLUSIVE of the provided moment =)
-	return nil
+	return differentiate(order, parse(b.LatestSince(moment, true), parseFn...))
+}
+
+// parse translates the yielded elements into float64 values, collapsing any elements which share a moment into the latest.
+func parse[T any](yield []instant[T], parseFn ...func(T) float64) []instant[float64] {
+	fn := func(element T) float64 {
+		if v, ok := asFloat(element); ok {
+			return v
+		}
+		panic(fmt.Sprintf("%T is not implicitly parseable - please provide a parseFn", element))
+	}
+	if len(parseFn) > 0 && parseFn[0] != nil {
+		fn = parseFn[0]
+	}
+
+	out := make([]instant[float64], 0, len(yield))
+	for _, inst := range yield {
+		value := fn(inst.Element)
+		if len(out) > 0 && out[len(out)-1].Moment.Equal(inst.Moment) {
+			out[len(out)-1].Element = value
+			continue
+		}
+		out = append(out, instant[float64]{inst.Moment, value})
+	}
+	return out
+}
+
+// asFloat implicitly converts any numeric primitive into a float64.
+func asFloat(element any) (float64, bool) {
+	switch v := element.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case uintptr:
+		return float64(v), true
+	case time.Duration:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func integrate(rule integration.Rule, points []instant[float64]) ([]instant[float64], float64) {
+	out := make([]instant[float64], len(points))
+	for i, p := range points {
+		out[i] = instant[float64]{Moment: p.Moment}
+	}
+
+	area := func(i int) float64 {
+		switch {
+		case rule == integration.Simpson && len(points) >= 3:
+			// Integrate the parabola through the interval's pair (or, for a trailing interval, the prior pair)
+			pivot := i - 1
+			if pivot%2 == 1 || pivot+2 >= len(points) {
+				pivot = i - 2
+			}
+			if pivot < 0 {
+				pivot = 0
+			}
+			return parabola(points[pivot], points[pivot+1], points[pivot+2]).integral(points[i-1].Moment, points[i].Moment)
+		default:
+			dt := points[i].Moment.Sub(points[i-1].Moment).Seconds()
+			return dt * (points[i].Element + points[i-1].Element) / 2
+		}
+	}
+
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		out[i].Element = area(i)
+		total += out[i].Element
+	}
+	return out, total
+}
+
+func differentiate(order uint, points []instant[float64]) []instant[float64] {
+	// NOTE: Only first and second order derivatives are supported
+	if order < 1 || order > 2 || len(points) < int(order)+1 {
+		return []instant[float64]{}
+	}
+
+	out := make([]instant[float64], len(points))
+	for i, p := range points {
+		if len(points) == 2 {
+			dt := points[1].Moment.Sub(points[0].Moment).Seconds()
+			out[i] = instant[float64]{p.Moment, (points[1].Element - points[0].Element) / dt}
+			continue
+		}
+
+		// Differentiate the parabola through the moment and its neighbors (or the nearest three, at the edges)
+		pivot := i - 1
+		if pivot < 0 {
+			pivot = 0
+		}
+		if pivot+2 >= len(points) {
+			pivot = len(points) - 3
+		}
+		q := parabola(points[pivot], points[pivot+1], points[pivot+2])
+		if order == 1 {
+			out[i] = instant[float64]{p.Moment, q.slope(p.Moment)}
+		} else {
+			out[i] = instant[float64]{p.Moment, q.curvature()}
+		}
+	}
+	return out
+}
+
+// quadratic is the parabola through three moments in Newton form, with time measured in seconds since the first.
+type quadratic struct {
+	origin time.Time
+	t1     float64
+	f0     float64
+	d1     float64
+	d2     float64
+}
+
+func parabola(a, b, c instant[float64]) quadratic {
+	t1 := b.Moment.Sub(a.Moment).Seconds()
+	t2 := c.Moment.Sub(a.Moment).Seconds()
+	d1 := (b.Element - a.Element) / t1
+	d2 := ((c.Element-b.Element)/(t2-t1) - d1) / t2
+	return quadratic{origin: a.Moment, t1: t1, f0: a.Element, d1: d1, d2: d2}
+}
+
+// antiderivative evaluates the integral of the parabola from its origin to the provided moment.
+func (q quadratic) antiderivative(moment time.Time) float64 {
+	t := moment.Sub(q.origin).Seconds()
+	return q.f0*t + q.d1*t*t/2 + q.d2*(t*t*t/3-q.t1*t*t/2)
+}
+
+func (q quadratic) integral(from time.Time, to time.Time) float64 {
+	return q.antiderivative(to) - q.antiderivative(from)
+}
+
+func (q quadratic) slope(moment time.Time) float64 {
+	t := moment.Sub(q.origin).Seconds()
+	return q.d1 + q.d2*(2*t-q.t1)
+}
+
+func (q quadratic) curvature() float64 {
+	return 2 * q.d2
 }
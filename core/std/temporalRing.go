@@ -0,0 +1,189 @@
+package std
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"git.ignitelabs.net/janos/core"
+	"git.ignitelabs.net/janos/core/sys/atlas"
+)
+
+// NewTemporalRing creates a temporal buffer which is backed by a growable ring, rather than a slice, for
+// high-frequency recording.  It observes the provided window of time - or, if no window is provided,
+// atlas.ObservanceWindow.  The resulting buffer shares the full TemporalBuffer API, but differs in three ways:
+//
+// 0 - Recording in temporal order appends in constant time without allocating, once the ring has grown to fit the window
+//
+// 1 - Rather than trimming on every access, a background trimmer trims the ring at atlas.TrimFrequency
+//
+// 2 - Reads only share the buffer's lock, so concurrent calls to Latest and Yield never block one another
+//
+// NOTE: Elements outside the observance window are still never yielded - the trimmer simply reclaims their space.  The
+// trimmer starts with the first recording, waiting on the buffer's Clock as of then, and runs until the buffer is closed
+// or the instance shuts down.  See TemporalBuffer.Close
+func NewTemporalRing[T any](window ...*time.Duration) *TemporalBuffer[T] {
+	b := NewTemporalBuffer[T](window...)
+	b.buffer = &temporalRing[T]{}
+	b.ring = true
+	return b
+}
+
+// trimRing trims the buffer every period of atlas.TrimFrequency, as observed through the provided clock, until stopped.
+func (b *TemporalBuffer[T]) trimRing(stop chan any, clock Clock) {
+	for core.Alive() {
+		select {
+		case <-stop:
+			return
+		case <-clock.After(_hertzToDuration(atlas.TrimFrequency)):
+		}
+
+		b.master.Lock()
+		b.trim()
+		b.master.Unlock()
+	}
+}
+
+// temporalSlice stores instants in a slice, inserting by sorted position and trimming by reslicing.
+type temporalSlice[T any] struct {
+	instants []instant[T]
+}
+
+func (s *temporalSlice[T]) insert(moment time.Time, element T) {
+	t := len(s.instants) - 1
+	for i := len(s.instants) - 1; i >= 0; i-- {
+		if s.instants[i].Moment.Before(moment) {
+			break
+		}
+		t--
+	}
+	t++
+	if t < 0 {
+		t = 0
+	}
+	s.instants = slices.Insert(s.instants, t, instant[T]{moment, element})
+}
+
+func (s *temporalSlice[T]) trim(cutoff time.Time, minimum int) {
+	s.instants = s.instants[expired(s.len(), s.at, cutoff, minimum):]
+}
+
+func (s *temporalSlice[T]) yield(cutoff time.Time, minimum int, depth int) []instant[T] {
+	return slices.Clone(s.instants[retained(s.len(), s.at, cutoff, minimum, depth):])
+}
+
+func (s *temporalSlice[T]) update(fn func(*instant[T]) bool) {
+	for i := len(s.instants) - 1; i >= 0; i-- {
+		if fn(&s.instants[i]) {
+			return
+		}
+	}
+}
+
+func (s *temporalSlice[T]) at(i int) *instant[T] {
+	return &s.instants[i]
+}
+
+func (s *temporalSlice[T]) len() int {
+	return len(s.instants)
+}
+
+// temporalRing stores instants in a ring whose capacity is always a power of two, doubling whenever it fills.
+type temporalRing[T any] struct {
+	ring  []instant[T]
+	head  int
+	count int
+}
+
+// at returns the instant at the provided logical index, where 0 is the oldest.
+func (r *temporalRing[T]) at(i int) *instant[T] {
+	return &r.ring[(r.head+i)&(len(r.ring)-1)]
+}
+
+func (r *temporalRing[T]) grow() {
+	size := len(r.ring) * 2
+	if size == 0 {
+		size = 64
+	}
+	ring := make([]instant[T], size)
+	for i := 0; i < r.count; i++ {
+		ring[i] = *r.at(i)
+	}
+	r.ring = ring
+	r.head = 0
+}
+
+func (r *temporalRing[T]) insert(moment time.Time, element T) {
+	if r.count == len(r.ring) {
+		r.grow()
+	}
+
+	// The fast path - the moment follows everything already recorded
+	if r.count == 0 || moment.After(r.at(r.count-1).Moment) {
+		*r.at(r.count) = instant[T]{moment, element}
+		r.count++
+		return
+	}
+
+	t := sort.Search(r.count, func(i int) bool {
+		return !r.at(i).Moment.Before(moment)
+	})
+	for i := r.count; i > t; i-- {
+		*r.at(i) = *r.at(i - 1)
+	}
+	*r.at(t) = instant[T]{moment, element}
+	r.count++
+}
+
+func (r *temporalRing[T]) trim(cutoff time.Time, minimum int) {
+	n := expired(r.count, r.at, cutoff, minimum)
+	var zero instant[T]
+	for i := 0; i < n; i++ {
+		// NOTE: Expired slots are zeroed so the ring doesn't hold their elements from the garbage collector
+		*r.at(i) = zero
+	}
+	r.head = (r.head + n) & (len(r.ring) - 1)
+	r.count -= n
+}
+
+func (r *temporalRing[T]) yield(cutoff time.Time, minimum int, depth int) []instant[T] {
+	first := retained(r.count, r.at, cutoff, minimum, depth)
+	out := make([]instant[T], r.count-first)
+	if len(out) == 0 {
+		return out
+	}
+
+	start := (r.head + first) & (len(r.ring) - 1)
+	n := copy(out, r.ring[start:min(start+len(out), len(r.ring))])
+	copy(out[n:], r.ring)
+	return out
+}
+
+func (r *temporalRing[T]) update(fn func(*instant[T]) bool) {
+	for i := r.count - 1; i >= 0; i-- {
+		if fn(r.at(i)) {
+			return
+		}
+	}
+}
+
+func (r *temporalRing[T]) len() int {
+	return r.count
+}
+
+// expired returns how many of the oldest instants fall at or before the cutoff, never leaving fewer than the minimum.
+func expired[T any](count int, at func(int) *instant[T], cutoff time.Time, minimum int) int {
+	n := sort.Search(count, func(i int) bool {
+		return at(i).Moment.After(cutoff)
+	})
+	return min(n, max(count-minimum, 0))
+}
+
+// retained returns the index of the oldest instant to yield - the first which hasn't expired, or which lies within the depth.
+func retained[T any](count int, at func(int) *instant[T], cutoff time.Time, minimum int, depth int) int {
+	first := expired(count, at, cutoff, minimum)
+	if depth > 0 {
+		first = max(first, count-depth)
+	}
+	return first
+}
@@ -0,0 +1,98 @@
+package std
+
+import (
+	"sort"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/interpolation"
+)
+
+// At estimates the value of the buffer at the provided moment using the provided interpolation mode, allowing the
+// buffer to be consumed as a continuous signal.  Moments after the newest recording hold its value, while moments
+// before the oldest recording cannot be known - in that case, or if the buffer is empty, false is returned.
+//
+// NOTE: If the elements are NOT implicitly parseable, this will panic.  In that case, please provide a 'parseFn'
+// which translates the buffered information into a float64.  Any numeric type is implicitly parseable.
+func (b *TemporalBuffer[T]) At(moment time.Time, mode interpolation.Mode, parseFn ...func(T) float64) (float64, bool) {
+	b.sanityCheck()
+	return at(parse(b.Yield(), parseFn...), moment, mode)
+}
+
+// Resample yields the buffer as a uniformly sampled series, estimating the value at every multiple of the provided
+// period between the oldest and newest recordings using the provided interpolation mode.
+//
+// NOTE: The samples are aligned to multiples of the period (since the Unix epoch), so series resampled from different
+// buffers at the same period line up with one another.
+//
+// See At
+func (b *TemporalBuffer[T]) Resample(period time.Duration, mode interpolation.Mode, parseFn ...func(T) float64) []instant[float64] {
+	b.sanityCheck()
+	if period <= 0 {
+		panic("a resampling period must be positive")
+	}
+
+	points := parse(b.Yield(), parseFn...)
+	if len(points) == 0 {
+		return []instant[float64]{}
+	}
+
+	first := points[0].Moment
+	last := points[len(points)-1].Moment
+	// NOTE: time.Truncate aligns to Go's zero time, so the offset is taken from the Unix epoch explicitly
+	offset := time.Duration(first.UnixNano() % int64(period))
+	if offset < 0 {
+		offset += period
+	}
+	moment := first
+	if offset > 0 {
+		moment = first.Add(period - offset)
+	}
+
+	out := make([]instant[float64], 0, int(last.Sub(moment)/period)+1)
+	for ; !moment.After(last); moment = moment.Add(period) {
+		value, _ := at(points, moment, mode)
+		out = append(out, instant[float64]{moment, value})
+	}
+	return out
+}
+
+func at(points []instant[float64], moment time.Time, mode interpolation.Mode) (float64, bool) {
+	if len(points) == 0 || moment.Before(points[0].Moment) {
+		return 0, false
+	}
+
+	// Find the first recording after the moment - the moment then lies within [i-1, i)
+	i := sort.Search(len(points), func(i int) bool {
+		return points[i].Moment.After(moment)
+	})
+	if i == len(points) {
+		return points[len(points)-1].Element, true
+	}
+
+	a, b := points[i-1], points[i]
+	if mode == interpolation.Step || moment.Equal(a.Moment) {
+		return a.Element, true
+	}
+
+	h := b.Moment.Sub(a.Moment).Seconds()
+	s := moment.Sub(a.Moment).Seconds() / h
+	if mode == interpolation.Linear || len(points) < 3 {
+		return a.Element + s*(b.Element-a.Element), true
+	}
+
+	// Cubic Hermite interpolation, with each tangent taken from the parabola through the recording and its neighbors
+	tangent := func(j int) float64 {
+		pivot := j - 1
+		if pivot < 0 {
+			pivot = 0
+		}
+		if pivot+2 >= len(points) {
+			pivot = len(points) - 3
+		}
+		return parabola(points[pivot], points[pivot+1], points[pivot+2]).slope(points[j].Moment)
+	}
+	m0, m1 := tangent(i-1)*h, tangent(i)*h
+
+	s2, s3 := s*s, s*s*s
+	return (2*s3-3*s2+1)*a.Element + (s3-2*s2+s)*m0 + (-2*s3+3*s2)*b.Element + (s3-s2)*m1, true
+}
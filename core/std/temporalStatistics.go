@@ -0,0 +1,121 @@
+package std
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// NOTE: Each of the rolling aggregates below is calculated over every recording currently within the buffer's observance
+// window, and yields NaN when the buffer is empty.  If the elements are NOT implicitly parseable, they will panic - in
+// that case, please provide a 'parseFn' which translates the buffered information into a float64.
+
+// Mean yields the arithmetic mean of the recordings within the observance window.
+func (b *TemporalBuffer[T]) Mean(parseFn ...func(T) float64) float64 {
+	b.sanityCheck()
+	return mean(values(parse(b.Yield(), parseFn...)))
+}
+
+// Min yields the smallest recording within the observance window.
+func (b *TemporalBuffer[T]) Min(parseFn ...func(T) float64) float64 {
+	b.sanityCheck()
+	v := values(parse(b.Yield(), parseFn...))
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	out := v[0]
+	for _, x := range v[1:] {
+		out = math.Min(out, x)
+	}
+	return out
+}
+
+// Max yields the largest recording within the observance window.
+func (b *TemporalBuffer[T]) Max(parseFn ...func(T) float64) float64 {
+	b.sanityCheck()
+	v := values(parse(b.Yield(), parseFn...))
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	out := v[0]
+	for _, x := range v[1:] {
+		out = math.Max(out, x)
+	}
+	return out
+}
+
+// Variance yields the population variance of the recordings within the observance window.
+func (b *TemporalBuffer[T]) Variance(parseFn ...func(T) float64) float64 {
+	b.sanityCheck()
+	v := values(parse(b.Yield(), parseFn...))
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	m := mean(v)
+	sum := 0.0
+	for _, x := range v {
+		sum += (x - m) * (x - m)
+	}
+	return sum / float64(len(v))
+}
+
+// Percentile yields the provided percentile [0, 100] of the recordings within the observance window, linearly
+// interpolating between the closest ranks.
+func (b *TemporalBuffer[T]) Percentile(percentile float64, parseFn ...func(T) float64) float64 {
+	b.sanityCheck()
+	if percentile < 0 || percentile > 100 {
+		panic("a percentile must be within [0, 100]")
+	}
+
+	v := values(parse(b.Yield(), parseFn...))
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	sort.Float64s(v)
+
+	rank := percentile / 100 * float64(len(v)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return v[lower] + (rank-float64(lower))*(v[upper]-v[lower])
+}
+
+// EMA yields the exponential moving average of the recordings within the observance window, oldest to newest.  As
+// recordings can be irregularly spaced, each is weighted by how much time has passed since the last - meaning a
+// recording's influence decays by a factor of e every time constant.
+func (b *TemporalBuffer[T]) EMA(timeConstant time.Duration, parseFn ...func(T) float64) float64 {
+	b.sanityCheck()
+	if timeConstant <= 0 {
+		panic("an EMA's time constant must be positive")
+	}
+
+	points := parse(b.Yield(), parseFn...)
+	if len(points) == 0 {
+		return math.NaN()
+	}
+
+	out := points[0].Element
+	for i := 1; i < len(points); i++ {
+		alpha := 1 - math.Exp(-float64(points[i].Moment.Sub(points[i-1].Moment))/float64(timeConstant))
+		out += alpha * (points[i].Element - out)
+	}
+	return out
+}
+
+func values(points []instant[float64]) []float64 {
+	out := make([]float64, len(points))
+	for i, p := range points {
+		out[i] = p.Element
+	}
+	return out
+}
+
+func mean(v []float64) float64 {
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+	return sum / float64(len(v))
+}
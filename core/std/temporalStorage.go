@@ -0,0 +1,129 @@
+package std
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"git.ignitelabs.net/janos/core"
+	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/segment"
+	"git.ignitelabs.net/janos/core/sys/shutdown"
+)
+
+// temporalStorage is the on-disk log a persistent TemporalBuffer appends its recordings to.
+type temporalStorage[T any] struct {
+	log   *segment.Log
+	codec Codec[T]
+}
+
+// persisted holds the Close function of every buffer with an open log, all of which are closed by a single
+// shutdown.Cleanup hook registered on the first call to Persist.
+var persisted = struct {
+	closers map[any]func() error
+	once    sync.Once
+	gate    sync.Mutex
+}{closers: make(map[any]func() error)}
+
+// track registers the buffer to be closed during the shutdown.Cleanup phase.
+func track(buffer any, named string, closeFn func() error) {
+	persisted.once.Do(func() {
+		core.OnShutdown(shutdown.Cleanup, "temporal storage", func(wg *sync.WaitGroup) {
+			persisted.gate.Lock()
+			closers := make(map[any]func() error, len(persisted.closers))
+			for buffer, closeFn := range persisted.closers {
+				closers[buffer] = closeFn
+			}
+			persisted.gate.Unlock()
+
+			for _, closeFn := range closers {
+				_ = closeFn()
+			}
+			wg.Done()
+		})
+	})
+
+	persisted.gate.Lock()
+	defer persisted.gate.Unlock()
+	persisted.closers[buffer] = func() error {
+		err := closeFn()
+		if err != nil {
+			rec.Errorf(named, "failed to close: %v\n", err)
+		}
+		return err
+	}
+}
+
+// untrack removes the buffer from the shutdown.Cleanup phase, as it's been closed.
+func untrack(buffer any) {
+	persisted.gate.Lock()
+	defer persisted.gate.Unlock()
+	delete(persisted.closers, buffer)
+}
+
+// Persist backs the buffer with an append-only segment log within the provided directory.  Any recordings already in
+// the log which fall within the observance window are first replayed into the buffer - allowing a restarted service
+// to pick up right where it left off - and every recording from then on is appended to the log as it's made.
+//
+// The log rotates and retains its segments according to the provided options.  If no MaxAge is provided, the log
+// retains the buffer's observance window as of the call.  The log is closed during the shutdown.Cleanup phase, or
+// when calling Close.
+//
+// NOTE: Recordings which fail to encode or append are reported through rec and otherwise remain in memory only.
+func (b *TemporalBuffer[T]) Persist(dir string, codec Codec[T], options ...segment.Options) error {
+	b.sanityCheck()
+
+	var o segment.Options
+	if len(options) > 0 {
+		o = options[0]
+	}
+	if o.MaxAge == 0 {
+		o.MaxAge = *b.Window
+	}
+
+	b.master.Lock()
+	defer b.master.Unlock()
+
+	if b.storage != nil {
+		return fmt.Errorf("temporal buffer already persisted to %s", b.storage.log.Dir())
+	}
+
+	log, err := segment.Open(dir, o)
+	if err != nil {
+		return err
+	}
+
+	since := b.Clock.Now().Add(-*b.Window)
+	err = log.Replay(since, func(moment time.Time, payload []byte) error {
+		element, err := codec.Decode(payload)
+		if err != nil {
+			return fmt.Errorf("failed to decode record at %v: %w", moment, err)
+		}
+		b.buffer.insert(moment, element)
+		return nil
+	})
+	if err != nil {
+		_ = log.Close()
+		return err
+	}
+	b.trim()
+
+	b.storage = &temporalStorage[T]{log: log, codec: codec}
+	track(b, dir, b.Close)
+	return nil
+}
+
+// persist appends a recording to the on-disk log, if the buffer has been persisted.
+func (b *TemporalBuffer[T]) persist(moment time.Time, element T) {
+	if b.storage == nil {
+		return
+	}
+
+	payload, err := b.storage.codec.Encode(element)
+	if err == nil {
+		err = b.storage.log.Append(moment, payload)
+	}
+	if err != nil {
+		rec.Errorf(b.storage.log.Dir(), "failed to persist recording at %v: %v\n", moment, err)
+	}
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/when"
+)
+
+func Test_VirtualClock_Advance(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+
+	late := clock.After(2 * time.Second)
+	early := clock.After(time.Second)
+	if clock.Waiters() != 2 {
+		t.Fatalf("expected 2 waiters, got %d", clock.Waiters())
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case moment := <-early:
+		if !moment.Equal(time.Unix(1, 0)) {
+			t.Errorf("early timer fired at %v", moment)
+		}
+	default:
+		t.Error("early timer did not fire")
+	}
+	select {
+	case <-late:
+		t.Error("late timer fired early")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-late:
+	default:
+		t.Error("late timer did not fire")
+	}
+	if clock.Waiters() != 0 {
+		t.Errorf("expected 0 waiters, got %d", clock.Waiters())
+	}
+}
+
+func Test_VirtualClock_Step(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	cortex := std.NewCortexWithClock("virtual", clock)
+	cortex.Frequency = 10 //hz
+	defer cortex.Shutdown()
+
+	var gate sync.Mutex
+	fired := make(map[string][]uint)
+	record := func(imp *std.Impulse) {
+		gate.Lock()
+		defer gate.Unlock()
+		fired[imp.Neuron.Named()] = append(fired[imp.Neuron.Named()], imp.Beat)
+	}
+
+	cortex.Synapses() <- std.NewSynapse(life.Looping, "always", record, when.Always())
+	cortex.Synapses() <- std.NewSynapse(life.Looping, "half", record, when.HalfSpeed(10.0))
+	cortex.Synapses() <- std.NewSynapse(life.Looping, "periodic", record, when.Periodically(300*time.Millisecond))
+	cortex.Synapses() <- std.NewSynapse(life.Impulse, "once", record, nil)
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first step wires the synapses in on the initial beat, then ten more beats follow
+	clock.Step(cortex)
+	clock.Step(cortex, 10)
+
+	if cortex.Beat() != 10 {
+		t.Fatalf("expected beat 10, got %d", cortex.Beat())
+	}
+
+	expected := map[string][]uint{
+		"always":   {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		"half":     {2, 4, 6, 8, 10},
+		"periodic": {3, 6, 9},
+		"once":     {0},
+	}
+
+	gate.Lock()
+	defer gate.Unlock()
+	for name, beats := range expected {
+		if !reflect.DeepEqual(fired[name], beats) {
+			t.Errorf("%s fired on beats %v, expected %v", name, fired[name], beats)
+		}
+	}
+}
+
+func Test_VirtualClock_Step_ForeignTimers(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := std.NewVirtualClock(start)
+	cortex := std.NewCortexWithClock("foreign", clock)
+	cortex.Frequency = 10 //hz
+	defer cortex.Shutdown()
+
+	// NOTE: A timer which isn't the cortex's beat (such as a supervisor's backoff) must not be mistaken for it
+	_ = clock.After(time.Hour)
+	if err := cortex.Spark(std.NewSynapse(life.Looping, "always", func(*std.Impulse) {}, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 6; i++ {
+		clock.Step(cortex)
+	}
+
+	if cortex.Beat() != 5 {
+		t.Errorf("expected beat 5, got %d", cortex.Beat())
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != 600*time.Millisecond {
+		t.Errorf("expected 600ms to have elapsed, got %v", elapsed)
+	}
+}
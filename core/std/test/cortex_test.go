@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// exited returns a channel closed once the cortex has run its deferrals while shutting down.
+func exited(cortex *std.Cortex) chan any {
+	done := make(chan any)
+	cortex.Deferrals() <- func(wg *sync.WaitGroup) {
+		close(done)
+		wg.Done()
+	}
+	return done
+}
+
+// await blocks until the provided channel closes, failing the test if it takes longer than five seconds.
+func await(t *testing.T, done chan any) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting on the cortex")
+	}
+}
+
+func Test_Cortex_ParentContext(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cortex := std.NewCortexWithContext(parent, "bound")
+	cortex.Frequency = 100 //hz
+	done := exited(cortex)
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	await(t, done)
+	if cortex.Alive() {
+		t.Fatal("expected cancelling the parent context to shut the cortex down")
+	}
+	if err := cortex.Shutdown(); !errors.Is(err, std.ErrCortexShutdown) {
+		t.Fatalf("expected the cancelled cortex to already be shut down, got %v", err)
+	}
+}
+
+func Test_Cortex_ImpulseContext(t *testing.T) {
+	cortex := std.NewCortex("impulsive")
+	cortex.Frequency = 100 //hz
+	done := exited(cortex)
+
+	contexts := make(chan context.Context, 1)
+	release := make(chan any)
+	cortex.Synapses() <- std.NewSynapse(life.Impulse, "observer", func(imp *std.Impulse) {
+		contexts <- imp.Context()
+		<-release
+	}, nil)
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := <-contexts
+	if ctx.Err() != nil {
+		t.Fatal("expected the impulse context to be live while the cortex is")
+	}
+	if err := cortex.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the impulse context to be cancelled by the shutdown")
+	}
+	release <- nil
+	await(t, done)
+}
+
+func Test_Cortex_Errors(t *testing.T) {
+	var uncreated std.Cortex
+	if err := uncreated.Spark(); !errors.Is(err, std.ErrCortexUncreated) {
+		t.Fatalf("expected sparking an uncreated cortex to fail, got %v", err)
+	}
+	if err := uncreated.Shutdown(); !errors.Is(err, std.ErrCortexUncreated) {
+		t.Fatalf("expected shutting down an uncreated cortex to fail, got %v", err)
+	}
+
+	cortex := std.NewCortex("once")
+	cortex.Frequency = 100 //hz
+	done := exited(cortex)
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cortex.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	await(t, done)
+	if err := cortex.Shutdown(); !errors.Is(err, std.ErrCortexShutdown) {
+		t.Fatalf("expected a second shutdown to fail, got %v", err)
+	}
+	if err := cortex.Spark(); !errors.Is(err, std.ErrCortexShutdown) {
+		t.Fatalf("expected re-sparking to fail, got %v", err)
+	}
+}
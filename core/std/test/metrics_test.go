@@ -0,0 +1,72 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/when"
+)
+
+func Test_Metrics_Counters(t *testing.T) {
+	rec.SetSinks(rec.NewMemory())
+	defer rec.SetSinks()
+
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	cortex := std.NewCortexWithClock("measured", clock)
+	cortex.Frequency = 10 //hz
+	defer cortex.Shutdown()
+
+	metrics := std.NewMetrics()
+	cortex.Measure(metrics)
+
+	cortex.Synapses() <- std.NewSynapse(life.Looping, "steady", func(imp *std.Impulse) {}, when.Always())
+	cortex.Synapses() <- std.NewSynapse(life.Looping, "faulty", func(imp *std.Impulse) {
+		if imp.Beat%2 == 1 {
+			panic("odd beat")
+		}
+	}, when.Always())
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+	clock.Step(cortex)
+	clock.Step(cortex, 4)
+
+	snapshot := metrics.Snapshot()
+	if len(snapshot) != 1 {
+		t.Fatalf("expected 1 measured cortex, got %d", len(snapshot))
+	}
+	measured := snapshot[0]
+	if measured.Cortex != "measured" || measured.Frequency != 10 {
+		t.Errorf("unexpected cortex identity %q at %vhz", measured.Cortex, measured.Frequency)
+	}
+	if measured.Beats != 5 {
+		t.Errorf("expected 5 beats, got %d", measured.Beats)
+	}
+	if measured.BeatPeriod != 100*time.Millisecond || measured.BeatDriftMax != 0 {
+		t.Errorf("expected a drift-free 100ms beat period, got %v drifting up to %v", measured.BeatPeriod, measured.BeatDriftMax)
+	}
+	if len(measured.Neurons) != 2 {
+		t.Fatalf("expected 2 measured neurons, got %d", len(measured.Neurons))
+	}
+
+	neurons := make(map[string]std.NeuronMetrics)
+	for _, n := range measured.Neurons {
+		neurons[n.Neuron] = n
+	}
+	steady, faulty := neurons["steady"], neurons["faulty"]
+	if steady.Activations != 5 || steady.Panics != 0 {
+		t.Errorf("expected 5 clean steady activations, got %d with %d panics", steady.Activations, steady.Panics)
+	}
+	if faulty.Activations != 5 || faulty.Panics != 2 {
+		t.Errorf("expected 5 faulty activations with 2 panics, got %d with %d panics", faulty.Activations, faulty.Panics)
+	}
+
+	cortex.Measure(nil)
+	clock.Step(cortex)
+	if beats := metrics.Snapshot()[0].Beats; beats != 5 {
+		t.Errorf("expected an unmeasured cortex to stop counting, got %d beats", beats)
+	}
+}
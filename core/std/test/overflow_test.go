@@ -0,0 +1,70 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/enum/overflow"
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// NOTE: These cortices and clusters are never sparked, so nothing drains their synaptic channels
+
+func Test_Overflow_Coalesce(t *testing.T) {
+	cortex := std.NewCortex("coalescing", 4)
+	cortex.Overflow.Impulses = overflow.Coalesce
+
+	for i := 0; i < 10; i++ {
+		cortex.Impulse()
+	}
+	if dropped := cortex.Dropped().Impulses; dropped != 9 {
+		t.Errorf("expected 9 coalesced impulses, got %d", dropped)
+	}
+}
+
+func Test_Overflow_Drop(t *testing.T) {
+	cortex := std.NewCortex("dropping", 2)
+	cortex.Overflow.Impulses = overflow.DropNewest
+	cortex.Overflow.Mutes = overflow.DropOldest
+
+	for i := 0; i < 5; i++ {
+		cortex.Impulse()
+		cortex.Mute()
+	}
+	dropped := cortex.Dropped()
+	if dropped.Impulses != 3 {
+		t.Errorf("expected 3 dropped impulses, got %d", dropped.Impulses)
+	}
+	if dropped.Mutes != 3 {
+		t.Errorf("expected 3 dropped mutes, got %d", dropped.Mutes)
+	}
+	if dropped.Synapses != 0 {
+		t.Errorf("expected no dropped synapses, got %d", dropped.Synapses)
+	}
+}
+
+func Test_Overflow_Cluster(t *testing.T) {
+	cortex := std.NewCortex("clustering", 2)
+	cluster := cortex.CreateCluster("cluster", nil, nil)
+	cluster.Overflow = overflow.DropNewest
+
+	neuron := std.NewNeuron("neuron", func(*std.Impulse) {}, nil)
+	cluster.Wire(life.Looping, neuron, neuron, neuron)
+	cluster.Wire(life.Impulse, neuron, neuron, neuron, neuron)
+
+	if dropped := cluster.Dropped(); dropped != 3 {
+		t.Errorf("expected 3 dropped neurons, got %d", dropped)
+	}
+
+	done := make(chan any)
+	go func() {
+		cluster.Wire(life.Triggered, neuron, neuron, neuron)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected a dropping cluster to never block")
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/std"
+)
+
+func Test_PhaseLock_Self(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	cortex := std.NewCortexWithClock("locked", clock)
+	cortex.Frequency = 10 //hz
+	cortex.PhaseLock = &std.PhaseLock{}
+	defer cortex.Shutdown()
+
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+	clock.Step(cortex, 8)
+
+	status := cortex.PhaseLocked()
+	if !status.Engaged || !status.Locked {
+		t.Fatalf("expected an engaged lock, got %+v", status)
+	}
+	if status.Error != 0 || status.Offset != 0 {
+		t.Errorf("expected no phase error, got %+v", status)
+	}
+	for _, moment := range cortex.Timeline() {
+		if moment.Sub(cortex.Inception())%(100*time.Millisecond) != 0 {
+			t.Errorf("beat at %v is off the phase grid", moment.Sub(cortex.Inception()))
+		}
+	}
+}
+
+func Test_PhaseLock_Follower(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	leader := std.NewCortexWithClock("leader", clock)
+	leader.Frequency = 10 //hz
+	defer leader.Shutdown()
+
+	follower := std.NewCortexWithClock("follower", clock)
+	follower.Frequency = 10 //hz
+	follower.PhaseLock = &std.PhaseLock{Leader: leader, Offset: math.Pi}
+	defer follower.Shutdown()
+
+	if err := leader.Spark(); err != nil {
+		t.Fatal(err)
+	}
+	if err := follower.Spark(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every 50ms one of the two cortices beats, alternating between them
+	for i := 0; i < 12; i++ {
+		clock.BlockUntil(2)
+		clock.Advance(50 * time.Millisecond)
+		leader.Settle()
+		follower.Settle()
+	}
+	clock.BlockUntil(2)
+
+	timeline := follower.Timeline()
+	for _, moment := range timeline {
+		if offset := moment.Sub(leader.Inception()) % (100 * time.Millisecond); offset != 50*time.Millisecond {
+			t.Errorf("follower beat at %v is not half a period from the leader", moment.Sub(leader.Inception()))
+		}
+	}
+	if len(timeline) != 6 {
+		t.Errorf("expected the follower to beat 6 times, got %d", len(timeline))
+	}
+	if status := follower.PhaseLocked(); !status.Locked {
+		t.Errorf("expected the follower to be locked, got %+v", status)
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/std"
+)
+
+func Test_Revelation_Diamond(t *testing.T) {
+	var reveals int
+	source := std.NewRevelation(func(time.Time) int {
+		reveals++
+		return reveals
+	})
+	left := std.NewRevelation(func(time.Time) int { return source.Value() * 2 })
+	right := std.NewRevelation(func(time.Time) int { return source.Value() * 3 })
+	sum := std.NewRevelation(func(time.Time) int { return left.Value() + right.Value() })
+
+	if err := left.DependsOn(source); err != nil {
+		t.Fatal(err)
+	}
+	_ = right.DependsOn(source)
+	_ = sum.DependsOn(left, right)
+
+	if got := sum.Reveal(); got != 5 || reveals != 1 {
+		t.Errorf("expected the source to be revealed once for a sum of 5, got %d after %d reveals", got, reveals)
+	}
+	if got := sum.Reveal(); got != 10 || reveals != 2 {
+		t.Errorf("expected a fresh source on the next reveal for a sum of 10, got %d after %d reveals", got, reveals)
+	}
+}
+
+func Test_Revelation_Cycle(t *testing.T) {
+	a := std.NewRevelation(func(time.Time) int { return 0 })
+	b := std.NewRevelation(func(time.Time) string { return "" })
+	c := std.NewRevelation(func(time.Time) float64 { return 0 })
+
+	_ = b.DependsOn(a)
+	_ = c.DependsOn(b)
+	if err := a.DependsOn(c); !errors.Is(err, std.ErrRevelationCycle) {
+		t.Errorf("expected a cycle to be detected, got %v", err)
+	}
+	if err := a.DependsOn(a); !errors.Is(err, std.ErrRevelationCycle) {
+		t.Errorf("expected a self-dependency to be detected, got %v", err)
+	}
+}
+
+func Test_Revelation_BeatMemoization(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	cortex := std.NewCortexWithClock("revealing", clock)
+	defer cortex.Shutdown()
+
+	var reveals int
+	source := std.NewRevelation(func(time.Time) int {
+		reveals++
+		return reveals
+	})
+	source.Cortex = cortex
+	doubled := std.NewRevelation(func(time.Time) int { return source.Value() * 2 })
+	_ = doubled.DependsOn(source)
+
+	cortex.Frequency = 10
+	_ = cortex.Spark()
+	clock.Step(cortex)
+
+	doubled.Reveal()
+	doubled.Reveal()
+	if reveals != 1 {
+		t.Errorf("expected the source to be revealed once within a beat, got %d", reveals)
+	}
+
+	source.Invalidate()
+	if !doubled.Stale() {
+		t.Errorf("expected the invalidation to propagate downstream")
+	}
+	if got := doubled.Reveal(); got != 4 || reveals != 2 {
+		t.Errorf("expected an invalidated source to be revealed afresh, got %d after %d reveals", got, reveals)
+	}
+
+	clock.Step(cortex)
+	doubled.Reveal()
+	if reveals != 3 {
+		t.Errorf("expected the source to be revealed again on the next beat, got %d", reveals)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/atlas"
+	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/shutdown"
+)
+
+func Test_Cortex_BoundedShutdown(t *testing.T) {
+	timeout := atlas.ShutdownTimeout
+	atlas.ShutdownTimeout = 200 * time.Millisecond
+	defer func() { atlas.ShutdownTimeout = timeout }()
+
+	mem := rec.NewMemory()
+	rec.SetSinks(mem)
+	defer rec.SetSinks()
+
+	cortex := std.NewCortex("bounded")
+	cortex.Frequency = 100 //hz
+
+	var gate sync.Mutex
+	var phases []shutdown.Phase
+	exited := make(chan any)
+	for _, phase := range shutdown.Phases {
+		cortex.OnShutdown(phase, phase.String(), func(wg *sync.WaitGroup) {
+			gate.Lock()
+			phases = append(phases, phase)
+			gate.Unlock()
+			if phase == shutdown.Exit {
+				close(exited)
+			}
+			wg.Done()
+		})
+	}
+
+	release := make(chan any)
+	defer close(release)
+	started := make(chan any)
+	cortex.Synapses() <- std.NewSynapse(life.Impulse, "stuck", func(imp *std.Impulse) {
+		close(started)
+		<-release
+	}, nil)
+	cortex.Deferrals() <- func(wg *sync.WaitGroup) {
+		<-release
+	}
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+
+	<-started
+	began := time.Now()
+	if err := cortex.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cortex never finished shutting down")
+	}
+	// NOTE: The stuck neuron and deferral share a single deadline, rather than each holding up their own phase
+	if elapsed := time.Since(began); elapsed >= 2*atlas.ShutdownTimeout {
+		t.Errorf("shutdown took %v", elapsed)
+	}
+
+	// NOTE: Once the deadline passes, the remaining phases start their hooks without waiting on them
+	eventually(t, func() bool {
+		gate.Lock()
+		defer gate.Unlock()
+		return len(phases) == len(shutdown.Phases)
+	})
+	gate.Lock()
+	if phases[0] != shutdown.Quiesce || phases[1] != shutdown.Drain {
+		t.Errorf("expected the phases within the deadline to run in order, got %v", phases)
+	}
+	gate.Unlock()
+
+	out := mem.String()
+	if !strings.Contains(out, "[bounded] Drain phase timed out after 200ms waiting on: bounded ⇝ stuck") {
+		t.Errorf("expected the stuck neuron to be reported, got:\n%s", out)
+	}
+	if !regexp.MustCompile(`\[bounded] Cleanup phase timed out after 200ms waiting on: .*deferral`).MatchString(out) {
+		t.Errorf("expected the stuck deferral to be reported, got:\n%s", out)
+	}
+}
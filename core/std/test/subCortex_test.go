@@ -0,0 +1,64 @@
+package test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+)
+
+func Test_SubCortex_Division(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	root := std.NewCortexWithClock("root", clock)
+	root.Frequency = 10 //hz
+
+	mid := root.CreateSubCortex(3)
+	leaf := mid.CreateSubCortex(2, "leaf")
+
+	var gate sync.Mutex
+	fired := make(map[string][]time.Duration)
+	bridges := make(map[string][]string)
+	record := func(imp *std.Impulse) {
+		gate.Lock()
+		defer gate.Unlock()
+		fired[imp.Neuron.Named()] = append(fired[imp.Neuron.Named()], imp.Clock().Now().Sub(root.Inception()))
+		bridges[imp.Neuron.Named()] = imp.Bridge
+	}
+
+	if err := leaf.Spark(std.NewSynapse(life.Looping, "leafTick", record, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := mid.Spark(std.NewSynapse(life.Looping, "midTick", record, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.Spark(); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Step(root, 12)
+
+	gate.Lock()
+	expected := map[string][]time.Duration{
+		"midTick":  {300 * time.Millisecond, 600 * time.Millisecond, 900 * time.Millisecond, 1200 * time.Millisecond},
+		"leafTick": {600 * time.Millisecond, 1200 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(fired, expected) {
+		t.Errorf("expected %v, got %v", expected, fired)
+	}
+	if path := bridges["leafTick"]; !reflect.DeepEqual(path, []string{"root", "root/3", "leaf", "leafTick"}) {
+		t.Errorf("unexpected bridge %v", path)
+	}
+	gate.Unlock()
+
+	if mid.Frequency != root.Frequency/3 {
+		t.Errorf("expected the sub-cortex to report a divided frequency, got %v", mid.Frequency)
+	}
+
+	if err := root.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return !mid.Alive() && !leaf.Alive() })
+}
@@ -0,0 +1,179 @@
+package test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/enum/restart"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/atlas"
+	"git.ignitelabs.net/janos/core/sys/rec"
+)
+
+// incarnations tracks the distinct impulses each neuron has been activated through.
+type incarnations struct {
+	gate     sync.Mutex
+	impulses map[string]map[*std.Impulse]struct{}
+	panics   int
+}
+
+func (in *incarnations) record(imp *std.Impulse) {
+	in.gate.Lock()
+	defer in.gate.Unlock()
+
+	if in.impulses == nil {
+		in.impulses = make(map[string]map[*std.Impulse]struct{})
+	}
+	if in.impulses[imp.Neuron.Named()] == nil {
+		in.impulses[imp.Neuron.Named()] = make(map[*std.Impulse]struct{})
+	}
+	in.impulses[imp.Neuron.Named()][imp] = struct{}{}
+}
+
+// flaky records the activation and then panics the first 'times' activations.
+func (in *incarnations) flaky(times int) func(*std.Impulse) {
+	return func(imp *std.Impulse) {
+		in.record(imp)
+
+		in.gate.Lock()
+		defer in.gate.Unlock()
+		if in.panics < times {
+			in.panics++
+			panic("flaky neuron")
+		}
+	}
+}
+
+func (in *incarnations) count(named string) int {
+	in.gate.Lock()
+	defer in.gate.Unlock()
+
+	return len(in.impulses[named])
+}
+
+func supervise(t *testing.T, in *incarnations, strategy restart.Strategy) *std.Cortex {
+	cortex := std.NewCortex("supervised")
+	cortex.Frequency = 1000 //hz
+
+	sup := std.NewSupervisor("supervisor", strategy,
+		std.NewSynapse(life.Looping, "flaky", in.flaky(2), nil),
+		std.NewSynapse(life.Looping, "steady", in.record, nil),
+	)
+	sup.Backoff = time.Millisecond
+	cortex.Synapses() <- sup.Synapse()
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+	return cortex
+}
+
+func eventually(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition was never met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// NOTE: This runs ahead of the other supervisor tests, as it adjusts atlas.ShutdownTimeout before any of their
+// supervisors are left stopping in the background
+func Test_Supervisor_BoundedStop(t *testing.T) {
+	timeout := atlas.ShutdownTimeout
+	atlas.ShutdownTimeout = 100 * time.Millisecond
+	defer func() { atlas.ShutdownTimeout = timeout }()
+
+	mem := rec.NewMemory()
+	rec.SetSinks(mem)
+	defer rec.SetSinks()
+
+	release := make(chan any)
+	defer close(release)
+	started := make(chan any)
+	var once sync.Once
+
+	cortex := std.NewCortex("stopping")
+	cortex.Frequency = 100 //hz
+	sup := std.NewSupervisor("supervisor", restart.OneForOne, std.NewSynapse(life.Looping, "stuck", func(imp *std.Impulse) {
+		once.Do(func() { close(started) })
+		<-release
+	}, nil))
+	if err := cortex.Spark(sup.Synapse()); err != nil {
+		t.Fatal(err)
+	}
+
+	<-started
+	if err := cortex.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, func() bool {
+		return strings.Contains(mem.String(), "[stopping ⇝ supervisor] timed out after 100ms stopping: stopping ⇝ stuck")
+	})
+}
+
+func Test_Supervisor_OneForOne(t *testing.T) {
+	in := &incarnations{}
+	cortex := supervise(t, in, restart.OneForOne)
+	defer cortex.Shutdown()
+
+	eventually(t, func() bool { return in.count("flaky") == 3 })
+	time.Sleep(50 * time.Millisecond)
+
+	if in.count("flaky") != 3 {
+		t.Errorf("expected flaky to be incarnated 3 times, got %d", in.count("flaky"))
+	}
+	if in.count("steady") != 1 {
+		t.Errorf("expected steady to be incarnated once, got %d", in.count("steady"))
+	}
+	if !cortex.Alive() {
+		t.Error("cortex should still be alive")
+	}
+}
+
+func Test_Supervisor_OneForAll(t *testing.T) {
+	in := &incarnations{}
+	cortex := supervise(t, in, restart.OneForAll)
+	defer cortex.Shutdown()
+
+	eventually(t, func() bool { return in.count("flaky") == 3 && in.count("steady") >= 2 })
+	time.Sleep(50 * time.Millisecond)
+
+	// NOTE: The flaky neuron can panic before its restarted sibling ever activates, so only its restart is guaranteed
+	if in.count("flaky") != 3 {
+		t.Errorf("expected flaky to be incarnated 3 times, got %d", in.count("flaky"))
+	}
+	if in.count("steady") < 2 {
+		t.Errorf("expected steady to be restarted alongside flaky, got %d incarnations", in.count("steady"))
+	}
+}
+
+func Test_Supervisor_Escalation(t *testing.T) {
+	in := &incarnations{}
+	cortex := std.NewCortex("escalating")
+	cortex.Frequency = 1000 //hz
+
+	workers := std.NewSupervisor("workers", restart.OneForOne, std.NewSynapse(life.Looping, "failing", in.flaky(1<<16), nil))
+	workers.MaxRestarts = 1
+	workers.Backoff = time.Millisecond
+
+	root := std.NewSupervisor("root", restart.OneForOne, workers.Synapse())
+	root.MaxRestarts = 1
+	root.Backoff = time.Millisecond
+
+	cortex.Synapses() <- root.Synapse()
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, func() bool { return !cortex.Alive() })
+
+	// The workers exhaust their budget twice (2 incarnations each) before the root exhausts its own
+	if in.count("failing") != 4 {
+		t.Errorf("expected the failing neuron to be incarnated 4 times, got %d", in.count("failing"))
+	}
+}
@@ -0,0 +1,218 @@
+package test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/integration"
+	"git.ignitelabs.net/janos/core/enum/interpolation"
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// record fills a buffer with fn sampled at the provided (irregular) offsets, in seconds.
+func record(fn func(float64) float64, offsets ...float64) (*std.TemporalBuffer[float64], time.Time) {
+	start := time.Unix(0, 0)
+	buffer := std.NewTemporalBuffer[float64]()
+	buffer.Clock = std.NewVirtualClock(start.Add(time.Second))
+	for _, offset := range offsets {
+		buffer.Record(start.Add(time.Duration(offset*float64(time.Second))), fn(offset))
+	}
+	return buffer, start
+}
+
+func near(t *testing.T, what string, got float64, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s: expected %v, got %v", what, want, got)
+	}
+}
+
+func Test_TemporalBuffer_Integrate(t *testing.T) {
+	linear, _ := record(func(t float64) float64 { return 2 * t }, 0, 0.1, 0.35, 0.5, 0.9, 1)
+	_, area := linear.Integrate(integration.Trapezoidal, -1)
+	near(t, "trapezoidal", area, 1)
+
+	quadratic, start := record(func(t float64) float64 { return t * t }, 0, 0.1, 0.35, 0.5, 0.9, 1)
+	areas, area := quadratic.Integrate(integration.Simpson, -1)
+	near(t, "simpson", area, 1.0/3)
+	near(t, "oldest interval", areas[0].Element, 0)
+	near(t, "first interval", areas[1].Element, 0.001/3)
+
+	_, area = quadratic.IntegrateSince(integration.Simpson, start.Add(500*time.Millisecond))
+	near(t, "simpson since", area, (1-0.125)/3)
+}
+
+func Test_TemporalBuffer_Differentiate(t *testing.T) {
+	offsets := []float64{0, 0.1, 0.35, 0.5, 0.9, 1}
+	quadratic, _ := record(func(t float64) float64 { return t * t }, offsets...)
+
+	first := quadratic.Differentiate(1, -1)
+	second := quadratic.Differentiate(2, -1)
+	if len(first) != len(offsets) || len(second) != len(offsets) {
+		t.Fatalf("expected %d derivatives, got %d and %d", len(offsets), len(first), len(second))
+	}
+	for i, offset := range offsets {
+		near(t, "first order", first[i].Element, 2*offset)
+		near(t, "second order", second[i].Element, 2)
+	}
+
+	short, _ := record(func(t float64) float64 { return 3 * t }, 0, 0.5)
+	if d := short.Differentiate(2, -1); len(d) != 0 {
+		t.Errorf("expected no second order derivative of two elements, got %v", d)
+	}
+	near(t, "two element slope", short.Differentiate(1, -1)[0].Element, 3)
+
+	for _, order := range []uint{0, 3} {
+		if d := quadratic.Differentiate(order, -1); len(d) != 0 {
+			t.Errorf("expected no derivatives of order %d, got %v", order, d)
+		}
+	}
+}
+
+func Test_TemporalBuffer_Calculate(t *testing.T) {
+	buffer, _ := record(func(t float64) float64 { return t }, 0, 0.25, 1)
+
+	var dts []time.Duration
+	results := buffer.Calculate(-1, func(dt time.Duration, v float64) any {
+		dts = append(dts, dt)
+		return v * 2
+	})
+	if len(results) != 3 || results[2].Element != 2.0 {
+		t.Fatalf("unexpected results %v", results)
+	}
+	if dts[0] >= 0 || dts[1] != 250*time.Millisecond || dts[2] != 750*time.Millisecond {
+		t.Errorf("unexpected dts %v", dts)
+	}
+
+	parsed, area := buffer.Integrate(integration.Trapezoidal, -1, func(v float64) float64 { return 1 })
+	near(t, "parseFn", area, 1)
+	if len(parsed) != 3 {
+		t.Errorf("expected 3 areas, got %d", len(parsed))
+	}
+}
+
+func Test_TemporalBuffer_At(t *testing.T) {
+	buffer, start := record(func(t float64) float64 { return t * t }, 0, 0.1, 0.35, 0.5, 0.9, 1)
+	moment := start.Add(700 * time.Millisecond)
+
+	if _, ok := buffer.At(start.Add(-time.Millisecond), interpolation.Linear); ok {
+		t.Errorf("expected no value before the oldest recording")
+	}
+
+	step, _ := buffer.At(moment, interpolation.Step)
+	near(t, "step", step, 0.25)
+	linear, _ := buffer.At(moment, interpolation.Linear)
+	near(t, "linear", linear, 0.25+0.5*(0.81-0.25))
+	cubic, _ := buffer.At(moment, interpolation.Cubic)
+	near(t, "cubic", cubic, 0.49)
+	held, _ := buffer.At(start.Add(2*time.Second), interpolation.Cubic)
+	near(t, "held", held, 1)
+}
+
+func Test_TemporalBuffer_Resample(t *testing.T) {
+	buffer, _ := record(func(t float64) float64 { return 4 * t }, 0.05, 0.35, 1)
+
+	samples := buffer.Resample(250*time.Millisecond, interpolation.Linear)
+	if len(samples) != 4 {
+		t.Fatalf("expected 4 samples, got %v", samples)
+	}
+	for i, sample := range samples {
+		near(t, "sample", sample.Element, float64(i+1))
+	}
+}
+
+func Test_TemporalBuffer_Resample_Epoch(t *testing.T) {
+	// NOTE: Go's zero time isn't a multiple of 7 seconds from the Unix epoch, so this would misalign if truncated
+	buffer, start := record(func(t float64) float64 { return 4 * t }, 1, 20)
+
+	samples := buffer.Resample(7*time.Second, interpolation.Linear)
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %v", samples)
+	}
+	for i, sample := range samples {
+		offset := time.Duration(7*(i+1)) * time.Second
+		if !sample.Moment.Equal(start.Add(offset)) {
+			t.Errorf("expected sample %d to be aligned to %v since the Unix epoch, got %v", i, offset, sample.Moment.Sub(start))
+		}
+		near(t, "sample", sample.Element, 4*offset.Seconds())
+	}
+}
+
+func Test_TemporalBuffer_Statistics(t *testing.T) {
+	buffer, _ := record(func(t float64) float64 { return t * 10 }, 0.1, 0.2, 0.3, 0.4)
+
+	near(t, "mean", buffer.Mean(), 2.5)
+	near(t, "min", buffer.Min(), 1)
+	near(t, "max", buffer.Max(), 4)
+	near(t, "variance", buffer.Variance(), 1.25)
+	near(t, "median", buffer.Percentile(50), 2.5)
+	near(t, "90th percentile", buffer.Percentile(90), 3.7)
+
+	alpha := 1 - math.Exp(-1)
+	ema := 1.0
+	for _, v := range []float64{2, 3, 4} {
+		ema += alpha * (v - ema)
+	}
+	near(t, "ema", buffer.EMA(100*time.Millisecond), ema)
+
+	if empty := std.NewTemporalBuffer[float64](); !math.IsNaN(empty.Mean()) {
+		t.Errorf("expected the mean of an empty buffer to be NaN")
+	}
+}
+
+func Test_TemporalBuffer_Persist(t *testing.T) {
+	dir := t.TempDir()
+	window := 10 * time.Second
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+
+	buffer := std.NewTemporalBuffer[float64](&window)
+	buffer.Clock = clock
+	if err := buffer.Persist(dir, std.JSONCodec[float64]{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		clock.Advance(time.Second)
+		buffer.Record(clock.Now(), float64(i))
+	}
+	_ = buffer.Close()
+
+	restored := std.NewTemporalBuffer[float64](&window)
+	restored.Clock = clock
+	if err := restored.Persist(dir, std.JSONCodec[float64]{}); err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	yield := restored.Yield()
+	if len(yield) != 10 || yield[0].Element != 10 || yield[9].Element != 19 {
+		t.Errorf("expected the last observance window to be restored, got %v", yield)
+	}
+}
+
+func Test_Timeline_Snapshot(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	timeline := std.NewTimeline(clock)
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		now := clock.Now()
+		timeline.Add(std.SynapticEvent{Inception: now, Activation: now, Completion: now.Add(time.Millisecond)})
+	}
+
+	var snapshot bytes.Buffer
+	if err := timeline.Snapshot(&snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := std.NewTimeline(std.NewVirtualClock(time.Unix(1000, 0)))
+	if err := replayed.Replay(&snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Len() != timeline.Len()+1 {
+		t.Fatalf("expected %d events, got %d", timeline.Len()+1, replayed.Len())
+	}
+	if got, want := replayed.Latest()[0], timeline.Latest()[0]; !got.Completion.Equal(want.Completion) {
+		t.Errorf("expected the replayed event to complete at %v, got %v", want.Completion, got.Completion)
+	}
+}
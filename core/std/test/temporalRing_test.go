@@ -0,0 +1,120 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/atlas"
+)
+
+func Test_TemporalRing_MatchesBuffer(t *testing.T) {
+	window := 5 * time.Second
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+
+	buffer := std.NewTemporalBuffer[int](&window)
+	ring := std.NewTemporalRing[int](&window)
+	defer ring.Close()
+	buffer.Clock = clock
+	ring.Clock = clock
+
+	// Record mostly in order, with the occasional late arrival, across several growths of the ring
+	for i := 0; i < 1000; i++ {
+		moment := clock.Now().Add(time.Duration(i) * 10 * time.Millisecond)
+		if i%7 == 0 {
+			moment = moment.Add(-35 * time.Millisecond)
+		}
+		buffer.Record(moment, i)
+		ring.Record(moment, i)
+	}
+	clock.Advance(7 * time.Second)
+
+	want := fmt.Sprint(buffer.Yield())
+	if got := fmt.Sprint(ring.Yield()); got != want {
+		t.Errorf("expected the ring to yield\n%v\ngot\n%v", want, got)
+	}
+	if got, want := fmt.Sprint(ring.Latest(3)), fmt.Sprint(buffer.Latest(3)); got != want {
+		t.Errorf("expected the latest three to be %v, got %v", want, got)
+	}
+}
+
+func Test_TemporalRing_Trimmer(t *testing.T) {
+	window := time.Second
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	ring := std.NewTemporalRing[int](&window)
+	ring.Clock = clock
+	defer ring.Close()
+
+	for i := 0; i < 100; i++ {
+		ring.Record(clock.Now().Add(time.Duration(i)*time.Millisecond), i)
+	}
+	clock.Advance(2 * time.Second)
+
+	// Expired elements are never yielded, even before the trimmer has reclaimed them
+	minimum := int(atlas.ObservedMinimum)
+	if yield := ring.Yield(); len(yield) != minimum || yield[0].Element != 100-minimum {
+		t.Fatalf("expected only the observed minimum to be yielded, got %v", yield)
+	}
+
+	eventually(t, func() bool {
+		clock.Advance(time.Millisecond)
+		return int(ring.Len()) == minimum
+	})
+}
+
+// benchmarkRecord records in temporal order at 1024 Hz, with every 64th recording arriving late.
+func benchmarkRecord(b *testing.B, buffer *std.TemporalBuffer[float64]) {
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	buffer.Clock = clock
+	period := time.Second / 1024
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		moment := clock.Now()
+		if i%64 == 0 {
+			moment = moment.Add(-3 * period)
+		}
+		buffer.Record(moment, float64(i))
+		clock.Advance(period)
+	}
+}
+
+// benchmarkYield yields a full observance window of 1024 Hz recordings from concurrent readers.
+func benchmarkYield(b *testing.B, buffer *std.TemporalBuffer[float64]) {
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	buffer.Clock = clock
+	for i := 0; i < 2048; i++ {
+		buffer.Record(clock.Now(), float64(i))
+		clock.Advance(time.Second / 1024)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = buffer.Latest(16)
+		}
+	})
+}
+
+func Benchmark_TemporalBuffer_Record(b *testing.B) {
+	benchmarkRecord(b, std.NewTemporalBuffer[float64]())
+}
+
+func Benchmark_TemporalRing_Record(b *testing.B) {
+	ring := std.NewTemporalRing[float64]()
+	defer ring.Close()
+	benchmarkRecord(b, ring)
+}
+
+func Benchmark_TemporalBuffer_Latest(b *testing.B) {
+	benchmarkYield(b, std.NewTemporalBuffer[float64]())
+}
+
+func Benchmark_TemporalRing_Latest(b *testing.B) {
+	ring := std.NewTemporalRing[float64]()
+	defer ring.Close()
+	benchmarkYield(b, ring)
+}
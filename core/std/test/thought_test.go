@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/std"
+)
+
+func Test_Thought_Update(t *testing.T) {
+	thought := std.NewThought(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			thought.Update(func(v int) int { return v + 1 })
+		}()
+	}
+	wg.Wait()
+
+	if thought.Load() != 100 || thought.Version() != 100 {
+		t.Errorf("expected 100 at version 100, got %d at version %d", thought.Load(), thought.Version())
+	}
+	if v := thought.Store(-1); v != 101 {
+		t.Errorf("expected storing to advance the version to 101, got %d", v)
+	}
+}
+
+func Test_Thought_Watch(t *testing.T) {
+	thought := std.NewThought("idle")
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher := thought.Watch(ctx)
+
+	thought.Store("thinking")
+	if v := <-watcher; v != 1 {
+		t.Errorf("expected version 1, got %d", v)
+	}
+
+	// A watcher which falls behind only observes the latest change
+	thought.Store("pondering")
+	thought.Store("revealed")
+	if v := <-watcher; v != 3 {
+		t.Errorf("expected the missed versions to coalesce into version 3, got %d", v)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-watcher:
+		if ok {
+			t.Errorf("expected no further versions once cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected the watcher to close once cancelled")
+	}
+}
+
+func Test_Thought_Impulse(t *testing.T) {
+	imp := &std.Impulse{Thought: std.NewThought(42)}
+	if v := imp.Thought.(*std.Thought[int]).Load(); v != 42 || imp.Thought.Ponder() != 42 {
+		t.Errorf("expected the impulse to carry 42, got %v", v)
+	}
+}
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core"
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/std/trace"
+	"git.ignitelabs.net/janos/core/sys/shutdown"
+	"git.ignitelabs.net/janos/core/sys/when"
+)
+
+func Test_Trace_Collector(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(0, 0))
+	cortex := std.NewCortexWithClock("traced", clock)
+	cortex.Frequency = 10 //hz
+	defer cortex.Shutdown()
+
+	collector := trace.NewCollector()
+	cortex.Trace(collector)
+
+	cortex.Synapses() <- std.NewSynapse(life.Looping, "half", func(imp *std.Impulse) {}, when.HalfSpeed(10.0))
+	if err := cortex.Spark(); err != nil {
+		t.Fatal(err)
+	}
+	clock.Step(cortex, 5)
+
+	spans := collector.Named("traced ⇝ half")
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	for i, span := range spans {
+		if span.Attributes["janos.beat"] != uint(2*(i+1)) {
+			t.Errorf("span %d beat was %v", i, span.Attributes["janos.beat"])
+		}
+		if span.Attributes["janos.count"] != uint(i) {
+			t.Errorf("span %d count was %v", i, span.Attributes["janos.count"])
+		}
+		if span.Attributes["janos.lifecycle"] != life.Looping.String() {
+			t.Errorf("span %d lifecycle was %v", i, span.Attributes["janos.lifecycle"])
+		}
+		if span.End.Before(span.Start) {
+			t.Errorf("span %d ended before it started", i)
+		}
+	}
+	if spans[0].TraceID != spans[1].TraceID {
+		t.Error("spans of the same impulse should share a trace")
+	}
+	if spans[0].SpanID == spans[1].SpanID {
+		t.Error("spans should have unique identifiers")
+	}
+}
+
+func Test_Trace_OTLPFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+	sink, err := trace.NewOTLPFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	span := std.Span{
+		Name:       "cortex ⇝ neuron",
+		Start:      time.Unix(1, 0),
+		End:        time.Unix(2, 0),
+		Attributes: map[string]any{"janos.beat": uint(42)},
+	}
+	if err = sink.Export(span); err != nil {
+		t.Fatal(err)
+	}
+	if err = sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					Name              string `json:"name"`
+					StartTimeUnixNano string `json:"startTimeUnixNano"`
+					Attributes        []struct {
+						Key   string `json:"key"`
+						Value struct {
+							IntValue string `json:"intValue"`
+						} `json:"value"`
+					} `json:"attributes"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err = json.Unmarshal(data, &request); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := request.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if encoded.Name != span.Name {
+		t.Errorf("expected name %q, got %q", span.Name, encoded.Name)
+	}
+	if encoded.StartTimeUnixNano != "1000000000" {
+		t.Errorf("expected start 1000000000, got %s", encoded.StartTimeUnixNano)
+	}
+	if len(encoded.Attributes) != 1 || encoded.Attributes[0].Value.IntValue != "42" {
+		t.Errorf("unexpected attributes %+v", encoded.Attributes)
+	}
+}
+
+func Test_Trace_OTLPFile_Shutdown(t *testing.T) {
+	// NOTE: Shutting the instance down exits the process, so this re-runs the test in a child process
+	if path := os.Getenv("OTLP_SHUTDOWN"); path != "" {
+		sink, err := trace.NewOTLPFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		core.OnShutdown(shutdown.Drain, "draining neuron", func(wg *sync.WaitGroup) {
+			_ = sink.Export(std.Span{Name: "drained", Start: time.Unix(1, 0), End: time.Unix(2, 0)})
+			wg.Done()
+		})
+		core.ShutdownNow()
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	cmd := exec.Command(os.Args[0], "-test.run=^Test_Trace_OTLPFile_Shutdown$")
+	cmd.Env = append(os.Environ(), "OTLP_SHUTDOWN="+path)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v: %s", err, out)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"name":"drained"`) {
+		t.Errorf("expected the span exported while draining to be written, got %q", data)
+	}
+}
@@ -1,12 +1,121 @@
 package std
 
-import "sync"
+import (
+	"context"
+	"sync"
+)
 
-type Thought struct {
-	Revelation any
-	Gate       sync.Mutex
+// A Thought holds a value which may be safely shared between neurons - even across cortices.  Every change to the
+// value increments the thought's version, and any number of watchers can be notified as the thought changes.
+//
+// NOTE: A Thought must not be copied after first use.  See NewThought
+type Thought[T any] struct {
+	revelation T
+	version    uint64
+	watchers   map[chan uint64]struct{}
+	gate       sync.RWMutex
 }
 
-func NewThought(revelation any) *Thought {
-	return &Thought{Revelation: revelation}
+// A Thinker is any Thought, regardless of what it's thinking about - allowing an Impulse to carry one.  To recover
+// the typed thought, simply type-assert it:
+//
+//	server := imp.Thought.(*std.Thought[*http.Server]).Load()
+type Thinker interface {
+	// Ponder returns the thought's current value, untyped.
+	Ponder() any
+
+	// Version returns the number of times the thought has changed.
+	Version() uint64
+
+	// Watch returns a channel which receives the thought's version every time it changes - see Thought.Watch.
+	Watch(ctx context.Context) <-chan uint64
+}
+
+// NewThought creates a new Thought holding the provided value at version 0.
+func NewThought[T any](revelation T) *Thought[T] {
+	return &Thought[T]{revelation: revelation}
+}
+
+// Load returns the thought's current value.
+func (t *Thought[T]) Load() T {
+	t.gate.RLock()
+	defer t.gate.RUnlock()
+
+	return t.revelation
+}
+
+// Ponder returns the thought's current value, untyped.
+func (t *Thought[T]) Ponder() any {
+	return t.Load()
+}
+
+// Version returns the number of times the thought has changed.
+func (t *Thought[T]) Version() uint64 {
+	t.gate.RLock()
+	defer t.gate.RUnlock()
+
+	return t.version
+}
+
+// Store replaces the thought's value, returning its new version.
+func (t *Thought[T]) Store(revelation T) uint64 {
+	return t.Update(func(T) T {
+		return revelation
+	})
+}
+
+// Update atomically replaces the thought's value with the result of fn, returning its new version.  No other
+// changes to the thought can occur while fn runs - making it safe to derive the new value from the current one.
+//
+// NOTE: fn must not call back into the thought, or it will deadlock.
+func (t *Thought[T]) Update(fn func(T) T) uint64 {
+	t.gate.Lock()
+	defer t.gate.Unlock()
+
+	t.revelation = fn(t.revelation)
+	t.version++
+	for watcher := range t.watchers {
+		notify(watcher, t.version)
+	}
+	return t.version
+}
+
+// Watch returns a channel which receives the thought's version every time it changes, until the provided context is
+// cancelled - at which point the channel is closed.
+//
+// NOTE: A watcher never blocks the thought - if a watcher falls behind, the versions it missed coalesce into the
+// latest, so it only ever observes the most recent change.
+func (t *Thought[T]) Watch(ctx context.Context) <-chan uint64 {
+	watcher := make(chan uint64, 1)
+
+	t.gate.Lock()
+	if t.watchers == nil {
+		t.watchers = make(map[chan uint64]struct{})
+	}
+	t.watchers[watcher] = struct{}{}
+	t.gate.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		t.gate.Lock()
+		defer t.gate.Unlock()
+
+		delete(t.watchers, watcher)
+		close(watcher)
+	})
+	return watcher
+}
+
+// notify sends the version to the watcher, replacing any version it hasn't yet received.
+func notify(watcher chan uint64, version uint64) {
+	for {
+		select {
+		case watcher <- version:
+			return
+		default:
+			select {
+			case <-watcher:
+			default:
+			}
+		}
+	}
 }
@@ -1,6 +1,8 @@
 package std
 
 import (
+	"encoding/json"
+	"io"
 	"time"
 )
 
@@ -10,16 +12,26 @@ type Timeline struct {
 	temporal *TemporalBuffer[SynapticEvent]
 }
 
-func NewTimeline() *Timeline {
+// NewTimeline creates a new Timeline which observes the provided Clock.
+//
+// NOTE: If no clock is provided, the SystemClock is implied.
+func NewTimeline(clock ...Clock) *Timeline {
+	c := SystemClock
+	if len(clock) > 0 && clock[0] != nil {
+		c = clock[0]
+	}
+
+	now := c.Now()
 	inception := SynapticEvent{
-		SynapseCreation: time.Now(),
-		Inception:       time.Now(),
-		Activation:      time.Now(),
-		Completion:      time.Now(),
+		SynapseCreation: now,
+		Inception:       now,
+		Activation:      now,
+		Completion:      now,
 	}
 	timeline := &Timeline{
 		temporal: NewTemporalBuffer[SynapticEvent](),
 	}
+	timeline.temporal.Clock = c
 	timeline.Add(inception)
 	return timeline
 }
@@ -28,12 +40,13 @@ func (t *Timeline) setCompleted(id uint64, moment time.Time) {
 	t.temporal.master.Lock()
 	defer t.temporal.master.Unlock()
 
-	for i := len(t.temporal.buffer) - 1; i >= 0; i-- {
-		if t.temporal.buffer[i].Element.id == id {
-			t.temporal.buffer[i].Element.Completion = moment
-			break
+	t.temporal.buffer.update(func(inst *instant[SynapticEvent]) bool {
+		if inst.Element.id == id {
+			inst.Element.Completion = moment
+			return true
 		}
-	}
+		return false
+	})
 }
 
 func (t *Timeline) Len() uint {
@@ -124,3 +137,62 @@ func (t *Timeline) TotalTime() time.Duration {
 	}
 	return latest[0].Element.Completion.Sub(latest[0].Element.Inception)
 }
+
+// snapshotEvent is the wire form of a SynapticEvent within a Timeline snapshot.
+type snapshotEvent struct {
+	ID              uint64    `json:"id"`
+	Beat            uint      `json:"beat"`
+	BeatPeriod      int       `json:"beatPeriod"`
+	Count           uint      `json:"count"`
+	SynapseCreation time.Time `json:"synapseCreation"`
+	Inception       time.Time `json:"inception"`
+	Activation      time.Time `json:"activation"`
+	Completion      time.Time `json:"completion"`
+}
+
+// Snapshot writes every event currently within the timeline's observance window to the provided writer, one JSON
+// object per line.  See Replay
+func (t *Timeline) Snapshot(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, e := range t.Yield() {
+		err := encoder.Encode(snapshotEvent{
+			ID:              e.id,
+			Beat:            e.beat,
+			BeatPeriod:      e.beatPeriod,
+			Count:           e.count,
+			SynapseCreation: e.SynapseCreation,
+			Inception:       e.Inception,
+			Activation:      e.Activation,
+			Completion:      e.Completion,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replay adds every event from a Snapshot back into the timeline, in temporal order alongside any already present.
+//
+// NOTE: Events which fall outside the timeline's observance window are trimmed as they're added, just as if they had been observed live.
+func (t *Timeline) Replay(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var e snapshotEvent
+		if err := decoder.Decode(&e); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		t.Add(SynapticEvent{
+			id:              e.ID,
+			beat:            e.Beat,
+			beatPeriod:      e.BeatPeriod,
+			count:           e.Count,
+			SynapseCreation: e.SynapseCreation,
+			Inception:       e.Inception,
+			Activation:      e.Activation,
+			Completion:      e.Completion,
+		})
+	}
+}
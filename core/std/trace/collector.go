@@ -0,0 +1,69 @@
+package trace
+
+import (
+	"sync"
+
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// A Collector is an in-memory std.SpanSink which retains every exported span - primarily useful for testing.
+type Collector struct {
+	spans []std.Span
+	gate  sync.Mutex
+}
+
+// NewCollector creates a new empty in-memory span Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		spans: make([]std.Span, 0),
+	}
+}
+
+// Export retains the provided spans.
+func (c *Collector) Export(spans ...std.Span) error {
+	c.gate.Lock()
+	defer c.gate.Unlock()
+
+	c.spans = append(c.spans, spans...)
+	return nil
+}
+
+// Spans returns a copy of every span collected so far, in export order.
+func (c *Collector) Spans() []std.Span {
+	c.gate.Lock()
+	defer c.gate.Unlock()
+
+	out := make([]std.Span, len(c.spans))
+	copy(out, c.spans)
+	return out
+}
+
+// Named returns a copy of every collected span with the provided name, in export order.
+func (c *Collector) Named(name string) []std.Span {
+	c.gate.Lock()
+	defer c.gate.Unlock()
+
+	out := make([]std.Span, 0)
+	for _, span := range c.spans {
+		if span.Name == name {
+			out = append(out, span)
+		}
+	}
+	return out
+}
+
+// Len returns the number of collected spans.
+func (c *Collector) Len() int {
+	c.gate.Lock()
+	defer c.gate.Unlock()
+
+	return len(c.spans)
+}
+
+// Reset discards every collected span.
+func (c *Collector) Reset() {
+	c.gate.Lock()
+	defer c.gate.Unlock()
+
+	c.spans = make([]std.Span, 0)
+}
@@ -0,0 +1,228 @@
+package trace
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"git.ignitelabs.net/janos/core"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/shutdown"
+)
+
+// An OTLPFile is a std.SpanSink which appends every export to a file as a single line of OTLP-JSON - the same encoding
+// the OpenTelemetry collector's file exporter emits, meaning the output can be replayed into any OTLP-compatible backend.
+type OTLPFile struct {
+	// Service is reported as the 'service.name' resource attribute - defaulting to the instance's core.Name.
+	Service string
+
+	file   *os.File
+	gate   sync.Mutex
+	unhook func()
+}
+
+// NewOTLPFile opens (or creates) the file at the provided path for appending OTLP-JSON spans.  The file is
+// automatically closed during the shutdown.Cleanup phase of the JanOS instance - after the Drain phase, so the spans
+// of any neurons which finish while draining are still written.
+func NewOTLPFile(path string) (*OTLPFile, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &OTLPFile{
+		Service: core.Name.Name,
+		file:    file,
+	}
+
+	o.unhook = core.OnShutdown(shutdown.Cleanup, fmt.Sprintf("OTLP file '%v'", path), func(wg *sync.WaitGroup) {
+		_ = o.Close()
+		wg.Done()
+	})
+	return o, nil
+}
+
+// Export appends the provided spans to the file as a single OTLP-JSON line.
+func (o *OTLPFile) Export(spans ...std.Span) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(encodeRequest(o.Service, spans))
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	o.gate.Lock()
+	defer o.gate.Unlock()
+
+	if o.file == nil {
+		return os.ErrClosed
+	}
+	_, err = o.file.Write(data)
+	return err
+}
+
+// Close flushes and closes the underlying file.
+func (o *OTLPFile) Close() error {
+	o.gate.Lock()
+	defer o.gate.Unlock()
+
+	if o.file == nil {
+		return nil
+	}
+	o.unhook()
+	_ = o.file.Sync()
+	err := o.file.Close()
+	o.file = nil
+	return err
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Events            []otlpEvent     `json:"events"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string `json:"timeUnixNano"`
+	Name         string `json:"name"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+// otlpSpanKindInternal is the OTLP span kind for operations which don't cross a process boundary.
+const otlpSpanKindInternal = 1
+
+func encodeRequest(service string, spans []std.Span) otlpRequest {
+	encoded := make([]otlpSpan, len(spans))
+	for i, span := range spans {
+		encoded[i] = otlpSpan{
+			TraceID:           hex.EncodeToString(span.TraceID[:]),
+			SpanID:            hex.EncodeToString(span.SpanID[:]),
+			Name:              span.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Attributes:        encodeAttributes(span.Attributes),
+			Events:            make([]otlpEvent, len(span.Events)),
+		}
+		for j, event := range span.Events {
+			encoded[i].Events[j] = otlpEvent{
+				TimeUnixNano: strconv.FormatInt(event.Moment.UnixNano(), 10),
+				Name:         event.Name,
+			}
+		}
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: encodeAttributes(map[string]any{"service.name": service}),
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "git.ignitelabs.net/janos/core/std"},
+						Spans: encoded,
+					},
+				},
+			},
+		},
+	}
+}
+
+func encodeAttributes(attributes map[string]any) []otlpAttribute {
+	out := make([]otlpAttribute, 0, len(attributes))
+	for key, value := range attributes {
+		out = append(out, otlpAttribute{Key: key, Value: encodeValue(value)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func encodeValue(value any) otlpValue {
+	integer := func(i int64) otlpValue {
+		s := strconv.FormatInt(i, 10)
+		return otlpValue{IntValue: &s}
+	}
+
+	switch typed := value.(type) {
+	case string:
+		return otlpValue{StringValue: &typed}
+	case bool:
+		return otlpValue{BoolValue: &typed}
+	case int:
+		return integer(int64(typed))
+	case int8:
+		return integer(int64(typed))
+	case int16:
+		return integer(int64(typed))
+	case int32:
+		return integer(int64(typed))
+	case int64:
+		return integer(typed)
+	case uint:
+		return integer(int64(typed))
+	case uint8:
+		return integer(int64(typed))
+	case uint16:
+		return integer(int64(typed))
+	case uint32:
+		return integer(int64(typed))
+	case uint64:
+		return integer(int64(typed))
+	case float32:
+		f := float64(typed)
+		return otlpValue{DoubleValue: &f}
+	case float64:
+		return otlpValue{DoubleValue: &typed}
+	default:
+		s := fmt.Sprintf("%v", typed)
+		return otlpValue{StringValue: &s}
+	}
+}
@@ -0,0 +1,7 @@
+// Package trace provides the standard std.SpanSink implementations for exporting traced synaptic activity.
+//
+// Tracing is opt-in, per cortex:
+//
+//	collector := trace.NewCollector()
+//	cortex.Trace(collector)
+package trace
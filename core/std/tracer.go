@@ -0,0 +1,108 @@
+package std
+
+import (
+	"encoding/binary"
+	"time"
+
+	"git.ignitelabs.net/janos/core/sys/rec"
+)
+
+// A Span is the traced form of a single SynapticEvent.  It's named after the impulse's Bridge and spans from the
+// event's inception to its completion.
+//
+// The following attributes are always provided:
+//
+//   - janos.cortex - the name of the cortex that fired the impulse
+//   - janos.neuron - the name of the neuron the impulse terminated into
+//   - janos.beat - the cortex beat the activation occurred on
+//   - janos.beatPeriod - the cortex beat period at activation
+//   - janos.count - the number of prior activations of the synapse
+//   - janos.lifecycle - the life.Cycle of the synapse
+//
+// Additionally, the SynapticEvent's lifecycle moments are provided as the span's events.
+type Span struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]any
+	Events     []SpanEvent
+}
+
+// A SpanEvent is a named moment within a Span.
+type SpanEvent struct {
+	Name   string
+	Moment time.Time
+}
+
+// A SpanSink receives the spans exported by a cortex's tracer.  Sinks must be safe for concurrent use, as spans are
+// exported from the neural goroutine which completed the event.
+//
+// See the trace package for the standard sinks.
+type SpanSink interface {
+	Export(spans ...Span) error
+}
+
+type tracer struct {
+	sink SpanSink
+}
+
+// Trace opts the cortex into tracing - every completed SynapticEvent will be exported to the provided sink as a Span.
+// Providing a nil sink disables tracing.
+func (ctx *Cortex) Trace(sink SpanSink) {
+	ctx.sanityCheck()
+
+	if sink == nil {
+		ctx.tracer.Store(nil)
+		return
+	}
+	ctx.tracer.Store(&tracer{sink: sink})
+}
+
+// trace exports the completed event to the cortex's tracer, if one is set.
+func (ctx *Cortex) trace(imp *Impulse, event *SynapticEvent, completion time.Time) {
+	t := ctx.tracer.Load()
+	if t == nil {
+		return
+	}
+
+	var neuron string
+	if len(imp.Bridge) > 0 {
+		neuron = imp.Bridge[len(imp.Bridge)-1]
+	}
+
+	span := Span{
+		TraceID: imp.traceID,
+		Name:    imp.Bridge.String(),
+		Start:   event.Inception,
+		End:     completion,
+		Attributes: map[string]any{
+			"janos.cortex":     ctx.Named(),
+			"janos.neuron":     neuron,
+			"janos.beat":       event.beat,
+			"janos.beatPeriod": event.beatPeriod,
+			"janos.count":      event.count,
+			"janos.lifecycle":  imp.lifecycle.String(),
+		},
+		Events: []SpanEvent{
+			{Name: "synapseCreation", Moment: event.SynapseCreation},
+			{Name: "inception", Moment: event.Inception},
+			{Name: "activation", Moment: event.Activation},
+			{Name: "completion", Moment: completion},
+		},
+	}
+	binary.BigEndian.PutUint64(span.SpanID[:], event.id)
+
+	if err := t.sink.Export(span); err != nil {
+		rec.Verbosef(imp.Bridge.String(), "trace export error: %v\n", err)
+	}
+}
+
+// newTraceID creates a trace identifier for an impulse fired from the provided cortex.
+func newTraceID(ctx *Cortex, impulse uint64) [16]byte {
+	var traceID [16]byte
+	binary.BigEndian.PutUint64(traceID[:8], ctx.GetID())
+	binary.BigEndian.PutUint64(traceID[8:], impulse)
+	return traceID
+}
@@ -0,0 +1,159 @@
+package std
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// A VirtualClock is a Clock that only moves when it's told to.  This allows temporal activity to be stepped
+// through deterministically - for example:
+//
+//	clock := std.NewVirtualClock(time.Unix(0, 0))
+//	cortex := std.NewCortexWithClock("test", clock)
+//	cortex.Frequency = 10 //hz
+//	cortex.Spark()
+//
+//	clock.Step(cortex, 10) // Advance exactly ten beats
+type VirtualClock struct {
+	now    time.Time
+	timers []*virtualTimer
+	beats  map[*Cortex]*virtualTimer // NOTE: beats holds each cortex's pending beat timer, allowing Step to wait on it specifically
+
+	master  sync.Mutex
+	waiters sync.Cond
+}
+
+type virtualTimer struct {
+	deadline time.Time
+	fire     chan time.Time
+	cortex   *Cortex
+}
+
+// NewVirtualClock creates a new VirtualClock which begins at the provided moment.
+//
+// NOTE: If no moment is provided, the current system time is implied.
+func NewVirtualClock(start ...time.Time) *VirtualClock {
+	now := time.Now()
+	if len(start) > 0 {
+		now = start[0]
+	}
+	c := &VirtualClock{now: now, beats: make(map[*Cortex]*virtualTimer)}
+	c.waiters = sync.Cond{L: &c.master}
+	return c
+}
+
+// Now returns the clock's current virtual moment.
+func (c *VirtualClock) Now() time.Time {
+	c.master.Lock()
+	defer c.master.Unlock()
+	return c.now
+}
+
+// After returns a channel which receives the virtual moment once the clock has been advanced by at least the provided duration.
+func (c *VirtualClock) After(d time.Duration) <-chan time.Time {
+	return c.after(d, nil)
+}
+
+// afterBeat is After, but registers the timer as the provided cortex's next beat.
+func (c *VirtualClock) afterBeat(cortex *Cortex, d time.Duration) <-chan time.Time {
+	return c.after(d, cortex)
+}
+
+func (c *VirtualClock) after(d time.Duration, cortex *Cortex) <-chan time.Time {
+	c.master.Lock()
+	defer c.master.Unlock()
+
+	t := &virtualTimer{
+		deadline: c.now.Add(d),
+		fire:     make(chan time.Time, 1),
+		cortex:   cortex,
+	}
+	if d <= 0 {
+		t.fire <- c.now
+		return t.fire
+	}
+	c.timers = append(c.timers, t)
+	if cortex != nil {
+		c.beats[cortex] = t
+	}
+	c.waiters.Broadcast()
+	return t.fire
+}
+
+// Sleep blocks until the clock has been advanced by at least the provided duration.
+func (c *VirtualClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance moves the clock forward by the provided duration, firing every timer whose deadline has been reached in temporal order.
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.master.Lock()
+	defer c.master.Unlock()
+
+	c.now = c.now.Add(d)
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].deadline.Before(c.timers[j].deadline)
+	})
+
+	var fired int
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			break
+		}
+		t.fire <- c.now
+		if t.cortex != nil && c.beats[t.cortex] == t {
+			delete(c.beats, t.cortex)
+		}
+		fired++
+	}
+	c.timers = c.timers[fired:]
+	c.waiters.Broadcast()
+}
+
+// Waiters returns the number of timers currently waiting on the clock.
+func (c *VirtualClock) Waiters() int {
+	c.master.Lock()
+	defer c.master.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil blocks until at least the provided number of timers are waiting on the clock.
+func (c *VirtualClock) BlockUntil(waiters int) {
+	c.master.Lock()
+	defer c.master.Unlock()
+	for len(c.timers) < waiters {
+		c.waiters.Wait()
+	}
+}
+
+// blockUntilBeat blocks until the provided cortex's next beat timer is waiting on the clock.
+func (c *VirtualClock) blockUntilBeat(cortex *Cortex) {
+	c.master.Lock()
+	defer c.master.Unlock()
+	for c.beats[cortex] == nil {
+		c.waiters.Wait()
+	}
+}
+
+// Step advances the clock through the provided number of the cortex's beats, one period at a time.  After each beat,
+// this blocks until the cortex has settled - meaning every neuron has finished reacting to it.
+//
+// NOTE: Step waits on the cortex's own beat timer, so any other timers waiting on the clock - such as those of a
+// supervisor or a potential - never cause it to advance early.
+//
+// NOTE: If no beats are provided, a single beat is implied.  The cortex must have a positive Frequency.
+func (c *VirtualClock) Step(cortex *Cortex, beats ...uint) {
+	n := uint(1)
+	if len(beats) > 0 {
+		n = beats[0]
+	}
+
+	for i := uint(0); i < n; i++ {
+		c.blockUntilBeat(cortex)
+		c.Advance(_hertzToDuration(cortex.Frequency))
+		c.blockUntilBeat(cortex)
+		cortex.Settle()
+	}
+}
@@ -6,14 +6,51 @@ import (
 	"io/fs"
 	"os"
 	"sync"
+	"time"
+
+	"git.ignitelabs.net/janos/core/sys/watch"
 )
 
 var keys = make(map[string]any)
 var gate sync.RWMutex
-var cleanup chan any
+var watcher *watch.Watcher
+
+var subscribers = make(map[uint64]func())
+var subscriberGate sync.Mutex
+var nextSubscriber uint64
 
 func init() {
-	cleanup, _ = watch(".", refresh)
+	refresh()
+	watcher, _ = watch.OnChange("atlas", func(watch.Event) {
+		refresh()
+
+		subscriberGate.Lock()
+		fns := make([]func(), 0, len(subscribers))
+		for _, fn := range subscribers {
+			fns = append(fns, fn)
+		}
+		subscriberGate.Unlock()
+
+		for _, fn := range fns {
+			fn()
+		}
+	}, watch.Options{Debounce: 100 * time.Millisecond})
+}
+
+// OnRefresh calls fn every time the atlas file changes on disk, once its new values have been applied - allowing
+// custom keys read through Parse to be hot-reloaded as well.  Call the returned function to stop being notified.
+func OnRefresh(fn func()) (cancel func()) {
+	subscriberGate.Lock()
+	defer subscriberGate.Unlock()
+
+	nextSubscriber++
+	key := nextSubscriber
+	subscribers[key] = fn
+	return func() {
+		subscriberGate.Lock()
+		defer subscriberGate.Unlock()
+		delete(subscribers, key)
+	}
 }
 
 func refresh() {
@@ -46,10 +83,10 @@ func refresh() {
 	}
 }
 
-// Cleanup is called by core on shutdown to ensure the file watchers are closed.
+// Cleanup is called by core on shutdown to ensure the file watcher is closed.
 func Cleanup() {
-	if cleanup != nil {
-		cleanup <- nil
+	if watcher != nil {
+		_ = watcher.Close()
 	}
 }
 
@@ -1,27 +1,32 @@
 package atlas
 
 import (
+	"log/slog"
+	"strings"
 	"time"
 
 	"git.ignitelabs.net/janos/core/sys/rec"
 )
 
 type config struct {
-	PrintPreamble        *bool         `json:"printPreamble"`
-	Verbose              *bool         `json:"verbose"`
-	Silent               *bool         `json:"silent"`
-	ShutdownTimeout      time.Duration `json:"shutdownTimeout"`
-	Record               []byte        `json:"record"`
-	ObservanceWindow     string        `json:"observanceWindow"`
-	ObservedMinimum      uint          `json:"observedMinimum"`
-	TrimFrequency        float64       `json:"trimFrequency"`
-	Precision            uint          `json:"precision"`
-	PrecisionMinimum     uint          `json:"precisionMinimum"`
-	Radix                uint          `json:"radix"`
-	SeedRefractoryPeriod string        `json:"seedRefractoryPeriod"`
-	IncludeNilBits       *bool         `json:"includeNilBits"`
-	CompactVectors       *bool         `json:"compactVectors"`
-	SynapticChannelLimit uint          `json:"synapticChannelLimit"`
+	PrintPreamble        *bool             `json:"printPreamble"`
+	Verbose              *bool             `json:"verbose"`
+	Silent               *bool             `json:"silent"`
+	LogLevel             string            `json:"logLevel"`
+	LogLevels            map[string]string `json:"logLevels"`
+	LogFormat            string            `json:"logFormat"`
+	ShutdownTimeout      time.Duration     `json:"shutdownTimeout"`
+	Record               []byte            `json:"record"`
+	ObservanceWindow     string            `json:"observanceWindow"`
+	ObservedMinimum      uint              `json:"observedMinimum"`
+	TrimFrequency        float64           `json:"trimFrequency"`
+	Precision            uint              `json:"precision"`
+	PrecisionMinimum     uint              `json:"precisionMinimum"`
+	Radix                uint              `json:"radix"`
+	SeedRefractoryPeriod string            `json:"seedRefractoryPeriod"`
+	IncludeNilBits       *bool             `json:"includeNilBits"`
+	CompactVectors       *bool             `json:"compactVectors"`
+	SynapticChannelLimit uint              `json:"synapticChannelLimit"`
 }
 
 func (c config) apply() {
@@ -34,6 +39,23 @@ func (c config) apply() {
 	if c.Silent != nil {
 		rec.Silent = *c.Silent
 	}
+	if len(c.LogLevel) > 0 {
+		if level, err := rec.ParseLevel(c.LogLevel); err == nil {
+			rec.SetThreshold(level)
+		}
+	}
+	if c.LogLevels != nil {
+		levels := make(map[string]slog.Level, len(c.LogLevels))
+		for name, l := range c.LogLevels {
+			if level, err := rec.ParseLevel(l); err == nil {
+				levels[name] = level
+			}
+		}
+		rec.SetLevels(levels)
+	}
+	if len(c.LogFormat) > 0 {
+		rec.SetJSON(strings.EqualFold(c.LogFormat, "json"))
+	}
 	if c.ShutdownTimeout != 0 {
 		ShutdownTimeout = c.ShutdownTimeout
 	}
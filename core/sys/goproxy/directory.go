@@ -0,0 +1,189 @@
+package goproxy
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// directorySource serves the modules held in a directory laid out as a module proxy.
+type directorySource struct {
+	dir string
+}
+
+// Directory serves modules from a directory laid out as a module proxy - such as the go command's own download cache,
+// found at $(go env GOMODCACHE)/cache/download, or the directory of a Cached source.  Only the versions with an info
+// file are listed, and the directory is never written to.
+func Directory(dir string) Source {
+	return &directorySource{dir: dir}
+}
+
+// file returns the path of a module's file within the directory.
+func (s *directorySource) file(module string, name string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(escape(module)), "@v", name)
+}
+
+func (s *directorySource) List(module string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(s.file(module, "list")))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, e := range entries {
+		if escaped, ok := strings.CutSuffix(e.Name(), ".info"); ok {
+			if version, err := unescape(escaped); err == nil && !pseudo(version) {
+				versions = append(versions, version)
+			}
+		}
+	}
+	return versions, nil
+}
+
+func (s *directorySource) Info(module string, version string) (Info, error) {
+	if !canonical(version) {
+		return Info{}, ErrNotFound
+	}
+	data, err := s.read(module, version, ".info")
+	if err != nil {
+		return Info{}, err
+	}
+	var info Info
+	if err = json.Unmarshal(data, &info); err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+func (s *directorySource) Mod(module string, version string) ([]byte, error) {
+	return s.read(module, version, ".mod")
+}
+
+func (s *directorySource) Zip(module string, version string, w io.Writer) error {
+	f, err := os.Open(s.file(module, escape(version)+".zip"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func (s *directorySource) read(module string, version string, ext string) ([]byte, error) {
+	data, err := os.ReadFile(s.file(module, escape(version)+ext))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// cachedSource serves from a directory, filling it from another source on each miss.
+type cachedSource struct {
+	source Source
+	cache  *directorySource
+}
+
+// Cached serves the source through a cache directory - each version's info, go.mod and zip are fetched from the
+// source once, then served from the directory from then on.  Lists and queries always consult the source, as they
+// change whenever a new version is released.
+//
+// NOTE: The cache directory is laid out as a module proxy, so it can later be served directly through Directory.
+func Cached(source Source, dir string) Source {
+	return &cachedSource{source: source, cache: &directorySource{dir: dir}}
+}
+
+func (s *cachedSource) List(module string) ([]string, error) {
+	return s.source.List(module)
+}
+
+func (s *cachedSource) Info(module string, version string) (Info, error) {
+	if info, err := s.cache.Info(module, version); err == nil {
+		return info, nil
+	}
+
+	info, err := s.source.Info(module, version)
+	if err != nil {
+		return Info{}, err
+	}
+	data, err := json.Marshal(info)
+	if err == nil {
+		_, _ = s.store(module, info.Version, ".info", func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		})
+	}
+	return info, nil
+}
+
+func (s *cachedSource) Mod(module string, version string) ([]byte, error) {
+	if mod, err := s.cache.Mod(module, version); err == nil {
+		return mod, nil
+	}
+
+	mod, err := s.source.Mod(module, version)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = s.store(module, version, ".mod", func(w io.Writer) error {
+		_, err := w.Write(mod)
+		return err
+	})
+	return mod, nil
+}
+
+func (s *cachedSource) Zip(module string, version string, w io.Writer) error {
+	cached, err := s.store(module, version, ".zip", func(f io.Writer) error {
+		return s.source.Zip(module, version, f)
+	})
+	if err != nil {
+		return err
+	}
+	if !cached {
+		// The cache couldn't be written to, so the zip is served straight from the source
+		return s.source.Zip(module, version, w)
+	}
+	return s.cache.Zip(module, version, w)
+}
+
+// store writes a file into the cache, unless it's already present - reporting whether the file is now cached.  Only
+// an error from write itself is returned, as a cache which can't be written to is simply bypassed.
+//
+// NOTE: Each file is written to a temporary file and then renamed into place, so a reader never observes a partial file
+func (s *cachedSource) store(module string, version string, ext string, write func(io.Writer) error) (bool, error) {
+	if !canonical(version) {
+		return false, nil
+	}
+	path := s.cache.file(module, escape(version)+ext)
+	if _, err := os.Stat(path); err == nil {
+		return true, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, nil
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".partial-*")
+	if err != nil {
+		return false, nil
+	}
+	defer os.Remove(f.Name())
+
+	if err = write(f); err != nil {
+		_ = f.Close()
+		return false, err
+	}
+	if err = f.Close(); err != nil {
+		return false, nil
+	}
+	return os.Rename(f.Name(), path) == nil, nil
+}
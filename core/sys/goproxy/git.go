@@ -0,0 +1,355 @@
+package goproxy
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gitSource serves the modules held in a directory of git repositories.
+type gitSource struct {
+	root   string
+	prefix string
+}
+
+// Git serves modules from a directory of git repositories - bare or otherwise - using the git command.  The prefix
+// is the import path the directory is published under, so the module 'prefix/name' is served from the repository at
+// 'root/name' (or 'root/name.git').  Modules within a subdirectory of a repository are found by their tags, following
+// the go command's conventions - the module 'prefix/name/sub' releases 'v1.2.3' through the tag 'sub/v1.2.3'.
+//
+// Releases are found through the repository's tags, and any branch, tag or commit may be requested by name - resolving
+// to a release if it names one, or to a pseudo-version otherwise.
+func Git(root string, prefix string) Source {
+	return &gitSource{root: root, prefix: strings.Trim(prefix, "/")}
+}
+
+var majorSuffix = regexp.MustCompile(`^v[2-9][0-9]*$|^v[1-9][0-9]+$`)
+
+// gitModule locates a module within a repository.
+type gitModule struct {
+	path   string
+	repo   string
+	subdir string // NOTE: subdir is the module's directory within the repository - empty for the repository root
+	major  string // NOTE: major is the module path's major version suffix, such as "v2" - empty for v0 and v1
+}
+
+// tag returns the tag a release of the module is published under.
+func (m gitModule) tag(version string) string {
+	if m.subdir == "" {
+		return version
+	}
+	return m.subdir + "/" + version
+}
+
+// accepts reports whether a release belongs to the module's major version.
+func (m gitModule) accepts(version string) bool {
+	if m.major == "" {
+		return major(version) == "v0" || major(version) == "v1"
+	}
+	return major(version) == m.major
+}
+
+func (s *gitSource) locate(module string) (gitModule, error) {
+	rest, ok := strings.CutPrefix(module, s.prefix+"/")
+	if !ok || rest == "" {
+		return gitModule{}, ErrNotFound
+	}
+
+	m := gitModule{path: module}
+	parts := strings.Split(rest, "/")
+	if len(parts) > 1 && majorSuffix.MatchString(parts[len(parts)-1]) {
+		m.major = parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+	for _, p := range parts {
+		if p == "" || p == "." || p == ".." {
+			return gitModule{}, ErrNotFound
+		}
+	}
+
+	// The longest path which names a repository holds the module
+	for i := len(parts); i > 0; i-- {
+		dir := filepath.Join(s.root, filepath.Join(parts[:i]...))
+		for _, candidate := range []string{dir, dir + ".git"} {
+			if isRepository(candidate) {
+				m.repo = candidate
+				m.subdir = strings.Join(parts[i:], "/")
+				return m, nil
+			}
+		}
+	}
+	return gitModule{}, ErrNotFound
+}
+
+func isRepository(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(dir, "HEAD"))
+	_, objects := os.Stat(filepath.Join(dir, "objects"))
+	return err == nil && objects == nil
+}
+
+// git runs a git command against the repository, returning its output.
+func git(repo string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+func (s *gitSource) List(module string) ([]string, error) {
+	m, err := s.locate(module)
+	if err != nil {
+		return nil, err
+	}
+	return s.releases(m)
+}
+
+// releases returns every release of the module, as found in the repository's tags.
+func (s *gitSource) releases(m gitModule) ([]string, error) {
+	out, err := git(m.repo, "for-each-ref", "--format=%(refname:strip=2)", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, tag := range strings.Fields(string(out)) {
+		version, ok := strings.CutPrefix(tag, m.tag(""))
+		if ok && canonical(version) && !pseudo(version) && m.accepts(version) {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// commit resolves a revision to its full hash and commit time.
+func commit(repo string, rev string) (string, time.Time, error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", time.Time{}, ErrNotFound
+	}
+	out, err := git(repo, "log", "-1", "--no-walk", "--format=%H %ct", rev+"^{commit}", "--")
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	hash, seconds, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	unix, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return hash, time.Unix(unix, 0).UTC(), nil
+}
+
+// revisionOf returns the revision a canonical version of the module is found at.
+func (m gitModule) revisionOf(version string) (string, error) {
+	if !m.accepts(version) {
+		return "", ErrNotFound
+	}
+	if pseudo(version) {
+		return revision(version), nil
+	}
+	return "refs/tags/" + m.tag(version), nil
+}
+
+func (s *gitSource) Info(module string, version string) (Info, error) {
+	m, err := s.locate(module)
+	if err != nil {
+		return Info{}, err
+	}
+
+	if canonical(version) {
+		rev, err := m.revisionOf(version)
+		if err != nil {
+			return Info{}, err
+		}
+		_, t, err := commit(m.repo, rev)
+		if err != nil {
+			return Info{}, err
+		}
+		return Info{Version: version, Time: t}, nil
+	}
+
+	if version == "latest" {
+		version = "HEAD"
+	}
+	hash, t, err := commit(m.repo, version)
+	if err != nil {
+		return Info{}, err
+	}
+
+	// A revision naming a release resolves to that release - otherwise, it's named by a pseudo-version
+	releases, err := s.releases(m)
+	if err != nil {
+		return Info{}, err
+	}
+	var base string
+	for _, release := range sorted(releases) {
+		tagged, _, err := commit(m.repo, "refs/tags/"+m.tag(release))
+		if err != nil {
+			continue
+		}
+		if tagged == hash {
+			return Info{Version: release, Time: t}, nil
+		}
+		if _, err := git(m.repo, "merge-base", "--is-ancestor", tagged, hash); err == nil {
+			base = release
+		}
+	}
+	return Info{Version: pseudoVersion(base, m.major, t.Format("20060102150405"), hash), Time: t}, nil
+}
+
+func (s *gitSource) Mod(module string, version string) ([]byte, error) {
+	m, err := s.locate(module)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := m.revisionOf(version)
+	if err != nil {
+		return nil, err
+	}
+	hash, _, err := commit(m.repo, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	mod, err := git(m.repo, "show", hash+":"+path.Join(m.subdir, "go.mod"))
+	if err != nil {
+		// NOTE: Modules without a go.mod file are given one naming only the module - as the go command does
+		return []byte("module " + module + "\n"), nil
+	}
+	return mod, nil
+}
+
+// Zip archives the module's tree, following the go command's rules for module zips: nested modules and vendored
+// packages are left out, and a module in a subdirectory inherits the repository's LICENSE if it has none of its own.
+func (s *gitSource) Zip(module string, version string, w io.Writer) error {
+	m, err := s.locate(module)
+	if err != nil {
+		return err
+	}
+	rev, err := m.revisionOf(version)
+	if err != nil {
+		return err
+	}
+	hash, _, err := commit(m.repo, rev)
+	if err != nil {
+		return err
+	}
+
+	archive, err := git(m.repo, "-c", "core.autocrlf=input", "-c", "core.eol=lf", "archive", "--format=tar", hash)
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string][]byte)
+	var order []string
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		files[header.Name] = data
+		order = append(order, header.Name)
+	}
+
+	within := func(name string) (string, bool) {
+		if m.subdir == "" {
+			return name, true
+		}
+		return strings.CutPrefix(name, m.subdir+"/")
+	}
+
+	// Directories holding their own go.mod are separate modules
+	var nested []string
+	for _, name := range order {
+		if rel, ok := within(name); ok && path.Base(rel) == "go.mod" && rel != "go.mod" {
+			nested = append(nested, path.Dir(rel)+"/")
+		}
+	}
+
+	if _, ok := files[path.Join(m.subdir, "go.mod")]; !ok && m.subdir != "" {
+		return ErrNotFound
+	}
+
+	out := zip.NewWriter(w)
+	prefix := module + "@" + version + "/"
+	add := func(name string, data []byte) error {
+		f, err := out.Create(prefix + name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}
+
+	hasLicense := false
+	for _, name := range order {
+		rel, ok := within(name)
+		if !ok || vendored(rel) || inside(nested, rel) {
+			continue
+		}
+		if rel == "LICENSE" {
+			hasLicense = true
+		}
+		if err := add(rel, files[name]); err != nil {
+			return err
+		}
+	}
+	if license, ok := files["LICENSE"]; ok && !hasLicense && m.subdir != "" {
+		if err := add("LICENSE", license); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// inside reports whether the file lies within any of the provided directories.
+func inside(dirs []string, name string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// vendored reports whether the file belongs to a vendored package - mirroring the go command, which keeps files
+// directly within a vendor directory (such as vendor/modules.txt) but leaves out the packages beneath it.
+//
+// NOTE: The offset below deliberately matches golang.org/x/mod/zip, so the zips hash identically to the go command's own
+func vendored(name string) bool {
+	var i int
+	if strings.HasPrefix(name, "vendor/") {
+		i += len("vendor/")
+	} else if j := strings.Index(name, "/vendor/"); j >= 0 {
+		i += len("/vendor/")
+	} else {
+		return false
+	}
+	return strings.Contains(name[i:], "/")
+}
@@ -0,0 +1,239 @@
+// Package goproxy serves Go modules over the GOPROXY protocol - allowing 'go get' and 'go mod download' to fetch
+// modules from a local directory of git repositories, or from a module cache.
+//
+// A Source provides the modules, and Handler serves them:
+//
+//	source := goproxy.Cached(goproxy.Git("/srv/git", "git.ignitelabs.net"), "/var/cache/goproxy")
+//	http.ListenAndServe(":4243", goproxy.Handler(source))
+//
+// Clients then point the go command at the proxy - disabling the checksum database for private modules:
+//
+//	GOPROXY=http://localhost:4243 GONOSUMDB=git.ignitelabs.net go mod download git.ignitelabs.net/janos/core@latest
+//
+// See https://go.dev/ref/mod#goproxy-protocol
+package goproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by a Source when it doesn't hold the requested module or version.
+var ErrNotFound = errors.New("not found")
+
+// Info describes a single version of a module.
+type Info struct {
+	Version string
+	Time    time.Time
+}
+
+// A Source provides the modules served by a proxy.
+type Source interface {
+	// List returns every known release of the module, in any order.
+	List(module string) ([]string, error)
+
+	// Info resolves the version - which, if not canonical, may be a query such as a branch or commit the Source
+	// understands.  The special query "latest" is consulted only when the module has no releases.
+	Info(module string, version string) (Info, error)
+
+	// Mod returns the go.mod file of the module at the canonical version.
+	Mod(module string, version string) ([]byte, error)
+
+	// Zip writes the module zip of the module at the canonical version.
+	Zip(module string, version string, w io.Writer) error
+}
+
+// Handler serves the source's modules over the GOPROXY protocol.  Requests for modules or versions the source doesn't
+// hold are answered with a 404, which the go command understands as "not found" rather than as a failure.
+func Handler(source Source) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		fail := func(err error) {
+			if errors.Is(err, ErrNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		if escaped, ok := strings.CutSuffix(path, "/@latest"); ok {
+			module, err := unescape(escaped)
+			if err != nil {
+				fail(err)
+				return
+			}
+			info, err := latest(source, module)
+			if err != nil {
+				fail(err)
+				return
+			}
+			serveInfo(w, info)
+			return
+		}
+
+		escaped, file, ok := strings.Cut(path, "/@v/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		module, err := unescape(escaped)
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		if file == "list" {
+			versions, err := source.List(module)
+			if err != nil {
+				fail(err)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = io.WriteString(w, strings.Join(sorted(versions), "\n"))
+			return
+		}
+
+		dot := strings.LastIndexByte(file, '.')
+		if dot < 0 {
+			http.NotFound(w, r)
+			return
+		}
+		version, err := unescape(file[:dot])
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		switch file[dot:] {
+		case ".info":
+			info, err := source.Info(module, version)
+			if err != nil {
+				fail(err)
+				return
+			}
+			serveInfo(w, info)
+		case ".mod":
+			if !canonical(version) {
+				fail(ErrNotFound)
+				return
+			}
+			mod, err := source.Mod(module, version)
+			if err != nil {
+				fail(err)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write(mod)
+		case ".zip":
+			if !canonical(version) {
+				fail(ErrNotFound)
+				return
+			}
+			// NOTE: The zip is buffered so a failure part way through can still be reported
+			var buffer bytes.Buffer
+			if err := source.Zip(module, version, &buffer); err != nil {
+				fail(err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = buffer.WriteTo(w)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// latest resolves the newest release of the module - preferring releases over pre-releases - and consults the source
+// only if the module has no releases at all.
+func latest(source Source, module string) (Info, error) {
+	versions, err := source.List(module)
+	if err != nil {
+		return Info{}, err
+	}
+
+	var newest, release string
+	for _, v := range sorted(versions) {
+		newest = v
+		if !prerelease(v) {
+			release = v
+		}
+	}
+	if release != "" {
+		newest = release
+	}
+	if newest == "" {
+		return source.Info(module, "latest")
+	}
+	return source.Info(module, newest)
+}
+
+func serveInfo(w http.ResponseWriter, info Info) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Version string
+		Time    string
+	}{info.Version, info.Time.UTC().Format(time.RFC3339)})
+}
+
+// sorted returns the canonical versions in ascending semantic order.
+func sorted(versions []string) []string {
+	out := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if canonical(v) && !pseudo(v) {
+			out = append(out, v)
+		}
+	}
+	slices.SortFunc(out, compare)
+	return slices.Compact(out)
+}
+
+// escape encodes a module path or version for use in a URL or file path - each uppercase letter becomes an
+// exclamation mark followed by its lowercase form, keeping paths distinct on case-insensitive file systems.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			b.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unescape reverses escape.  See escape
+func unescape(s string) (string, error) {
+	var b strings.Builder
+	bang := false
+	for _, r := range s {
+		switch {
+		case bang:
+			if r < 'a' || r > 'z' {
+				return "", errors.New("invalid escaped path '" + s + "'")
+			}
+			b.WriteRune(r - ('a' - 'A'))
+			bang = false
+		case r == '!':
+			bang = true
+		case 'A' <= r && r <= 'Z':
+			return "", errors.New("invalid escaped path '" + s + "'")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if bang || s == "" || strings.Contains(s, "..") {
+		return "", errors.New("invalid escaped path '" + s + "'")
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,119 @@
+package goproxy
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NOTE: Only canonical semantic versions are served - 'v1.2' or 'v1.2.3+build' are never listed or fetched
+
+var canonicalPattern = regexp.MustCompile(`^v(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+var pseudoPattern = regexp.MustCompile(`(^|[-.])\d{14}-[0-9a-f]{12}$`)
+
+// canonical reports whether the version is a canonical semantic version.
+func canonical(v string) bool {
+	return canonicalPattern.MatchString(v)
+}
+
+// pseudo reports whether the version is a pseudo-version - one naming a commit, rather than a release.
+func pseudo(v string) bool {
+	return canonical(v) && strings.Count(v, "-") >= 2 && pseudoPattern.MatchString(v)
+}
+
+// prerelease reports whether the version is a pre-release.
+func prerelease(v string) bool {
+	return strings.Contains(v, "-")
+}
+
+// major returns the major version of a canonical version - such as "v2".
+func major(v string) string {
+	m, _, _ := strings.Cut(v, ".")
+	return m
+}
+
+// compare orders two canonical versions by semantic precedence.
+func compare(a string, b string) int {
+	aCore, aPre, _ := strings.Cut(a[1:], "-")
+	bCore, bPre, _ := strings.Cut(b[1:], "-")
+
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
+	for i := range aParts {
+		if c := compareNumeric(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+
+	aIDs := strings.Split(aPre, ".")
+	bIDs := strings.Split(bPre, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		aNum := isNumeric(aIDs[i])
+		bNum := isNumeric(bIDs[i])
+		var c int
+		switch {
+		case aNum && bNum:
+			c = compareNumeric(aIDs[i], bIDs[i])
+		case aNum:
+			c = -1
+		case bNum:
+			c = 1
+		default:
+			c = strings.Compare(aIDs[i], bIDs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return len(aIDs) - len(bIDs)
+}
+
+func isNumeric(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// compareNumeric orders two decimal strings without parsing them - they may exceed any integer type.
+func compareNumeric(a string, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// pseudoVersion builds the pseudo-version of a commit, given the release it descends from - if any.  See
+// https://go.dev/ref/mod#pseudo-versions
+func pseudoVersion(base string, majorVersion string, timestamp string, hash string) string {
+	suffix := timestamp + "-" + hash[:12]
+	switch {
+	case base == "":
+		if majorVersion == "" {
+			majorVersion = "v0"
+		}
+		return majorVersion + ".0.0-" + suffix
+	case prerelease(base):
+		return base + ".0." + suffix
+	default:
+		parts := strings.Split(base, ".")
+		patch, _ := strconv.ParseUint(parts[2], 10, 64)
+		return parts[0] + "." + parts[1] + "." + strconv.FormatUint(patch+1, 10) + "-0." + suffix
+	}
+}
+
+// revision returns the commit hash prefix a pseudo-version names.
+func revision(v string) string {
+	return v[len(v)-12:]
+}
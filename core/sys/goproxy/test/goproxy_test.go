@@ -0,0 +1,206 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"git.ignitelabs.net/janos/core/sys/goproxy"
+)
+
+// repository creates a git repository of the provided name beneath root, returning a function which writes the
+// provided files, commits them, and applies any tags.
+func repository(t *testing.T, root string, name string) func(files map[string]string, tags ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := filepath.Join(root, name)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.test", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run("init", "-q")
+
+	return func(files map[string]string, tags ...string) {
+		t.Helper()
+		for name, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		run("add", "-A")
+		run("commit", "-q", "-m", "commit")
+		for _, tag := range tags {
+			run("tag", tag)
+		}
+	}
+}
+
+func get(t *testing.T, server *httptest.Server, path string) (int, []byte) {
+	t.Helper()
+	response, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	return response.StatusCode, body
+}
+
+func fixture(t *testing.T) (string, *httptest.Server) {
+	root := t.TempDir()
+	commit := repository(t, root, "hello")
+	commit(map[string]string{
+		"go.mod":   "module example.test/hello\n\ngo 1.21\n",
+		"hello.go": "package hello\n\nfunc Hello() string { return \"hello\" }\n",
+		"LICENSE":  "license\n",
+	}, "v1.0.0")
+	commit(map[string]string{
+		"hello.go":             "package hello\n\nfunc Hello() string { return \"hello, world\" }\n",
+		"sub/go.mod":           "module example.test/hello/sub\n\ngo 1.21\n",
+		"sub/sub.go":           "package sub\n",
+		"vendor/modules.txt":   "# vendored\n",
+		"vendor/dep/vendor.go": "package dep\n",
+	}, "v1.1.0", "v1.2.0-beta.1", "sub/v0.1.0")
+	commit(map[string]string{"hello.go": "package hello\n\nfunc Hello() string { return \"unreleased\" }\n"})
+
+	untagged := repository(t, root, "untagged")
+	untagged(map[string]string{"go.mod": "module example.test/untagged\n", "u.go": "package untagged\n"})
+
+	server := httptest.NewServer(goproxy.Handler(goproxy.Cached(goproxy.Git(root, "example.test"), t.TempDir())))
+	t.Cleanup(server.Close)
+	return root, server
+}
+
+func Test_GoProxy_Protocol(t *testing.T) {
+	_, server := fixture(t)
+
+	if status, body := get(t, server, "/example.test/hello/@v/list"); status != 200 || string(body) != "v1.0.0\nv1.1.0\nv1.2.0-beta.1" {
+		t.Fatalf("unexpected list %d %q", status, body)
+	}
+
+	var info goproxy.Info
+	_, body := get(t, server, "/example.test/hello/@latest")
+	if err := json.Unmarshal(body, &info); err != nil || info.Version != "v1.1.0" {
+		t.Fatalf("expected the latest release to be v1.1.0, got %s", body)
+	}
+
+	if _, body = get(t, server, "/example.test/hello/@v/v1.0.0.mod"); !strings.HasPrefix(string(body), "module example.test/hello") {
+		t.Fatalf("unexpected go.mod %q", body)
+	}
+
+	// A revision - escaped, as with any version - resolves to a pseudo-version descending from the newest release
+	_, body = get(t, server, "/example.test/hello/@v/!h!e!a!d.info")
+	if err := json.Unmarshal(body, &info); err != nil || !strings.HasPrefix(info.Version, "v1.2.0-beta.1.0.") {
+		t.Fatalf("expected a pseudo-version based on v1.2.0-beta.1, got %s", body)
+	}
+
+	if status, _ := get(t, server, "/example.test/missing/@v/list"); status != http.StatusNotFound {
+		t.Fatalf("expected missing modules to 404, got %d", status)
+	}
+	if status, _ := get(t, server, "/example.test/hello/@v/v9.9.9.info"); status != http.StatusNotFound {
+		t.Fatalf("expected missing versions to 404, got %d", status)
+	}
+}
+
+func Test_GoProxy_Zip(t *testing.T) {
+	_, server := fixture(t)
+
+	zipFiles := func(path string) []string {
+		status, body := get(t, server, path)
+		if status != 200 {
+			t.Fatalf("%v: %d %s", path, status, body)
+		}
+		reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, f := range reader.File {
+			names = append(names, f.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	expected := []string{
+		"example.test/hello@v1.1.0/LICENSE",
+		"example.test/hello@v1.1.0/go.mod",
+		"example.test/hello@v1.1.0/hello.go",
+		"example.test/hello@v1.1.0/vendor/modules.txt",
+	}
+	if names := zipFiles("/example.test/hello/@v/v1.1.0.zip"); strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the nested module and vendored packages to be left out, got %v", names)
+	}
+
+	expected = []string{
+		"example.test/hello/sub@v0.1.0/LICENSE",
+		"example.test/hello/sub@v0.1.0/go.mod",
+		"example.test/hello/sub@v0.1.0/sub.go",
+	}
+	if names := zipFiles("/example.test/hello/sub/@v/v0.1.0.zip"); strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected the subdirectory module to inherit the license, got %v", names)
+	}
+}
+
+// download runs 'go mod download' against the proxy, returning the module cache it downloaded into.
+func download(t *testing.T, proxy string, modules ...string) string {
+	t.Helper()
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not available")
+	}
+
+	cache := t.TempDir()
+	cmd := exec.Command(goBin, append([]string{"mod", "download", "-json"}, modules...)...)
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(),
+		"GOPROXY="+proxy,
+		"GOSUMDB=off",
+		"GOMODCACHE="+cache,
+		"GOFLAGS=-modcacherw",
+		"GOWORK=off",
+		"GOTOOLCHAIN=local",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go mod download: %v\n%s", err, out)
+	}
+	return cache
+}
+
+func Test_GoProxy_Download(t *testing.T) {
+	_, server := fixture(t)
+
+	cache := download(t, server.URL, "example.test/hello@v1.1.0", "example.test/hello/sub@latest", "example.test/untagged@latest")
+	source, err := os.ReadFile(filepath.Join(cache, "example.test", "hello@v1.1.0", "hello.go"))
+	if err != nil || !strings.Contains(string(source), "hello, world") {
+		t.Fatalf("expected the module to be extracted into the cache: %v", err)
+	}
+
+	// The go command's own download cache can itself be served
+	mirror := httptest.NewServer(goproxy.Handler(goproxy.Directory(filepath.Join(cache, "cache", "download"))))
+	defer mirror.Close()
+	download(t, mirror.URL, "example.test/hello@v1.1.0", "example.test/hello/sub@v0.1.0")
+}
@@ -0,0 +1,87 @@
+package rec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+var jsonFormat atomic.Bool
+
+// JSON reports whether recordings are emitted as JSON objects rather than in the classic "[name] message" text form.
+func JSON() bool {
+	return jsonFormat.Load()
+}
+
+// SetJSON sets whether recordings are emitted as JSON objects rather than in the classic "[name] message" text form.
+func SetJSON(enabled bool) {
+	jsonFormat.Store(enabled)
+}
+
+// handler is the slog.Handler which backs every recording.
+type handler struct {
+	name   string
+	attrs  []slog.Attr
+	prefix string
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return Enabled(h.name, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	return h.write(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := *h
+	out.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		a.Key = h.prefix + a.Key
+		out.attrs = append(out.attrs, a)
+	}
+	return &out
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	out := *h
+	out.prefix = h.prefix + name + "."
+	return &out
+}
+
+// write formats the record and delivers it to every sink.
+func (h *handler) write(ctx context.Context, r slog.Record) error {
+	msg := strings.TrimSuffix(r.Message, "\n")
+
+	attrs := make([]slog.Attr, 0, len(h.attrs)+r.NumAttrs())
+	attrs = append(attrs, h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		a.Key = h.prefix + a.Key
+		attrs = append(attrs, a)
+		return true
+	})
+
+	var buf bytes.Buffer
+	if jsonFormat.Load() {
+		out := slog.NewRecord(r.Time, r.Level, msg, r.PC)
+		out.AddAttrs(attrs...)
+		j := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}).WithAttrs([]slog.Attr{slog.String("name", h.name)})
+		if err := j.Handle(ctx, out); err != nil {
+			return err
+		}
+	} else {
+		buf.WriteString(fmt.Sprintf("[%v] %v", h.name, msg))
+		for _, a := range attrs {
+			buf.WriteString(fmt.Sprintf(" %s=%v", a.Key, a.Value))
+		}
+		buf.WriteByte('\n')
+	}
+
+	return deliver(buf.Bytes())
+}
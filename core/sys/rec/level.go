@@ -0,0 +1,96 @@
+package rec
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+var minimum = slog.LevelInfo
+var overrides = make(map[string]slog.Level)
+var overrideGate sync.RWMutex // NOTE: overrideGate guards both the minimum and overrides, which atlas may reload at any time
+
+// Threshold returns the global minimum level a recording must meet to be emitted - defaulting to slog.LevelInfo.
+//
+// NOTE: Setting Verbose lowers the effective threshold to slog.LevelDebug.
+func Threshold() slog.Level {
+	overrideGate.RLock()
+	defer overrideGate.RUnlock()
+
+	return minimum
+}
+
+// SetThreshold sets the global minimum level a recording must meet to be emitted.
+func SetThreshold(level slog.Level) {
+	overrideGate.Lock()
+	defer overrideGate.Unlock()
+
+	minimum = level
+}
+
+// SetLevel overrides the threshold for recordings made against the provided name.  When a recording is made against
+// an impulse's Bridge (i.e. "cortex ⇝ neuron"), an override for any component of the bridge applies - with the most
+// specific (rightmost) component taking precedence over the whole.
+func SetLevel(name string, level slog.Level) {
+	overrideGate.Lock()
+	defer overrideGate.Unlock()
+
+	overrides[name] = level
+}
+
+// ClearLevel removes the threshold override for the provided name.
+func ClearLevel(name string) {
+	overrideGate.Lock()
+	defer overrideGate.Unlock()
+
+	delete(overrides, name)
+}
+
+// SetLevels replaces every threshold override with the provided set.
+func SetLevels(levels map[string]slog.Level) {
+	overrideGate.Lock()
+	defer overrideGate.Unlock()
+
+	overrides = make(map[string]slog.Level, len(levels))
+	for name, level := range levels {
+		overrides[name] = level
+	}
+}
+
+// ParseLevel parses a level name ("debug", "info", "warn", or "error" - case-insensitive) into a slog.Level.
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	err := l.UnmarshalText([]byte(level))
+	return l, err
+}
+
+// Enabled reports whether a recording against the provided name at the provided level would be emitted.
+func Enabled(name string, level slog.Level) bool {
+	if Silent {
+		return false
+	}
+	return level >= threshold(name)
+}
+
+// threshold resolves the effective threshold for the provided name.
+func threshold(name string) slog.Level {
+	overrideGate.RLock()
+	defer overrideGate.RUnlock()
+
+	if l, ok := overrides[name]; ok {
+		return l
+	}
+	if len(overrides) > 0 {
+		components := strings.Split(name, " ⇝ ")
+		for i := len(components) - 1; i >= 0; i-- {
+			if l, ok := overrides[components[i]]; ok {
+				return l
+			}
+		}
+	}
+
+	if Verbose && minimum > slog.LevelDebug {
+		return slog.LevelDebug
+	}
+	return minimum
+}
@@ -1,48 +1,101 @@
+// Package rec provides JanOS's leveled recording system, built atop log/slog.
+//
+// Every recording is made against a name - typically a module identifier or an impulse's Bridge - and is emitted to
+// the configured sinks in either the classic "[name] message" text form or as JSON.  The threshold of what gets
+// recorded can be set globally or overridden per name, both of which can be driven from the atlas file.
 package rec
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"strings"
+	"time"
 )
 
-// Verbose sets whether the system should emit more verbose recordings or not.
+// Verbose sets whether the system should emit more verbose recordings or not.  When true, Debug recordings are emitted
+// regardless of the Threshold.
 var Verbose bool
 
 // Silent sets whether the system should stop emitting recordings entirely or not.
+//
+// NOTE: Fatal recordings will always reach os.Stderr, even when silent.
 var Silent bool
 
-// Verbosef prepends the provided string format with a name identifier and then prints it to the console, but only if Verbose is true.
+// Debugf records the provided string format against the name at the Debug level.
+func Debugf(name string, format string, a ...any) {
+	record(slog.LevelDebug, name, fmt.Sprintf(format, a...))
+}
+
+// Infof records the provided string format against the name at the Info level.
+func Infof(name string, format string, a ...any) {
+	record(slog.LevelInfo, name, fmt.Sprintf(format, a...))
+}
+
+// Warnf records the provided string format against the name at the Warn level.
+func Warnf(name string, format string, a ...any) {
+	record(slog.LevelWarn, name, fmt.Sprintf(format, a...))
+}
+
+// Errorf records the provided string format against the name at the Error level.
+func Errorf(name string, format string, a ...any) {
+	record(slog.LevelError, name, fmt.Sprintf(format, a...))
+}
+
+// Verbosef prepends the provided string format with a name identifier and then records it, but only if Debug
+// recordings are enabled for the name (see Verbose).
 func Verbosef(name string, format string, a ...any) {
-	if Silent {
-		return
-	}
-	if Verbose {
-		fmt.Printf("[%v] %v", name, fmt.Sprintf(format, a...))
-	}
+	Debugf(name, format, a...)
 }
 
-// Printf prepends the provided string format with a mnameodule identifier and then prints it to the console.
+// Printf prepends the provided string format with a name identifier and then records it at the Info level.
 func Printf(name string, format string, a ...any) {
-	if Silent {
-		return
-	}
-	fmt.Printf("[%v] %v", name, fmt.Sprintf(format, a...))
+	Infof(name, format, a...)
 }
 
-// Fatalf prepends the provided string format with a name identifier, prints it to the std.Err, and then calls os.Exit(1).
+// Fatalf prepends the provided string format with a name identifier, records it, ensures it reaches os.Stderr, and then calls os.Exit(1).
 func Fatalf(name string, format string, a ...any) {
-	if Silent {
-		return
-	}
-	fmt.Printf("[%v] %v", name, fmt.Sprintf(format, a...))
-	os.Exit(1)
+	FatalfCode(1, name, format, a...)
 }
 
-// FatalfCode prepends the provided string format with a name identifier, prints it to the std.Err, and then calls os.Exit(exitCode).
+// FatalfCode prepends the provided string format with a name identifier, records it, ensures it reaches os.Stderr, and then calls os.Exit(exitCode).
 func FatalfCode(exitCode int, name string, format string, a ...any) {
-	if Silent {
-		return
+	msg := fmt.Sprintf(format, a...)
+
+	delivered := false
+	if !Silent {
+		delivered = emit(slog.LevelError, name, msg) && stderrSink()
+	}
+	if !delivered {
+		_, _ = fmt.Fprintf(os.Stderr, "[%v] %v", name, terminate(msg))
 	}
-	_, _ = fmt.Fprintf(os.Stderr, "[%v] %v", name, fmt.Sprintf(format, a...))
 	os.Exit(exitCode)
 }
+
+// Logger returns a structured slog.Logger which records against the provided name through the same thresholds and sinks.
+func Logger(name string) *slog.Logger {
+	return slog.New(&handler{name: name})
+}
+
+// record emits the message if the name is enabled at the provided level.
+func record(level slog.Level, name string, msg string) {
+	if !Enabled(name, level) {
+		return
+	}
+	emit(level, name, msg)
+}
+
+// emit writes the message to the sinks regardless of threshold, returning whether it was written.
+func emit(level slog.Level, name string, msg string) bool {
+	r := slog.NewRecord(time.Now(), level, msg, 0)
+	return (&handler{name: name}).write(context.Background(), r) == nil
+}
+
+// terminate ensures the message ends with a newline, as recordings are line oriented.
+func terminate(msg string) string {
+	if strings.HasSuffix(msg, "\n") {
+		return msg
+	}
+	return msg + "\n"
+}
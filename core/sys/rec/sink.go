@@ -0,0 +1,219 @@
+package rec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+var sinks = []io.Writer{os.Stdout}
+var sinkGate sync.Mutex
+
+// SetSinks replaces the destinations every recording is delivered to.  Any io.Writer can act as a sink - the standard
+// options are os.Stdout (the default), os.Stderr, a RotatingFile, or an in-memory Memory sink.
+//
+// NOTE: If no sinks are provided, os.Stdout is implied.
+func SetSinks(sink ...io.Writer) {
+	sinkGate.Lock()
+	defer sinkGate.Unlock()
+
+	if len(sink) == 0 {
+		sinks = []io.Writer{os.Stdout}
+		return
+	}
+	sinks = append([]io.Writer{}, sink...)
+}
+
+// AddSink adds a destination which every recording is also delivered to.
+func AddSink(sink io.Writer) {
+	sinkGate.Lock()
+	defer sinkGate.Unlock()
+
+	sinks = append(sinks, sink)
+}
+
+// deliver writes a single formatted recording to every sink.
+func deliver(line []byte) error {
+	sinkGate.Lock()
+	defer sinkGate.Unlock()
+
+	var errs []error
+	for _, sink := range sinks {
+		if _, err := sink.Write(line); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stderrSink reports whether os.Stderr is one of the current sinks.
+func stderrSink() bool {
+	sinkGate.Lock()
+	defer sinkGate.Unlock()
+
+	for _, sink := range sinks {
+		if sink == os.Stderr {
+			return true
+		}
+	}
+	return false
+}
+
+// A RotatingFile is a sink which appends recordings to a file, rotating it out once it grows beyond MaxBytes.  Rotated
+// files are suffixed with their generation - "janos.log.1" being the most recent - and only MaxBackups are retained.
+type RotatingFile struct {
+	Path       string
+	MaxBytes   int64
+	MaxBackups int
+
+	file *os.File
+	size int64
+	gate sync.Mutex
+}
+
+// NewRotatingFile opens (or creates) the file at the provided path for appending recordings.
+func NewRotatingFile(path string, maxBytes int64, maxBackups int) (*RotatingFile, error) {
+	r := &RotatingFile{
+		Path:       path,
+		MaxBytes:   maxBytes,
+		MaxBackups: maxBackups,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// Write appends the recording, rotating the file first if the recording would grow it beyond MaxBytes.
+//
+// NOTE: If rotation fails, the recording is still appended to the current file and the rotation error is returned.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.gate.Lock()
+	defer r.gate.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	var rotation error
+	if r.MaxBytes > 0 && r.size > 0 && r.size+int64(len(p)) > r.MaxBytes {
+		if rotation = r.rotate(); r.file == nil {
+			return 0, rotation
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	if err == nil {
+		err = rotation
+	}
+	return n, err
+}
+
+// rotate shifts every retained generation back by one and starts a fresh file.  Should the shift fail, the original
+// path is reopened so the sink keeps recording - the file is only left nil if it can't be reopened at all.
+func (r *RotatingFile) rotate() error {
+	err := r.file.Close()
+	r.file = nil
+
+	if err == nil {
+		if r.MaxBackups <= 0 {
+			_ = os.Remove(r.Path)
+		} else {
+			_ = os.Remove(fmt.Sprintf("%s.%d", r.Path, r.MaxBackups))
+			for i := r.MaxBackups - 1; i >= 1; i-- {
+				_ = os.Rename(fmt.Sprintf("%s.%d", r.Path, i), fmt.Sprintf("%s.%d", r.Path, i+1))
+			}
+			err = os.Rename(r.Path, r.Path+".1")
+		}
+	}
+
+	if openErr := r.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	return err
+}
+
+// Close closes the underlying file.
+func (r *RotatingFile) Close() error {
+	r.gate.Lock()
+	defer r.gate.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Memory is an in-memory sink which retains every recording as a line - primarily useful for testing.
+type Memory struct {
+	lines []string
+	gate  sync.Mutex
+}
+
+// NewMemory creates a new empty in-memory sink.
+func NewMemory() *Memory {
+	return &Memory{
+		lines: make([]string, 0),
+	}
+}
+
+// Write retains the recording.
+func (m *Memory) Write(p []byte) (int, error) {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(p), "\n"), "\n") {
+		m.lines = append(m.lines, line)
+	}
+	return len(p), nil
+}
+
+// Lines returns a copy of every retained recording, in order.
+func (m *Memory) Lines() []string {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	out := make([]string, len(m.lines))
+	copy(out, m.lines)
+	return out
+}
+
+// String returns every retained recording joined as text.
+func (m *Memory) String() string {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	if len(m.lines) == 0 {
+		return ""
+	}
+	return strings.Join(m.lines, "\n") + "\n"
+}
+
+// Reset discards every retained recording.
+func (m *Memory) Reset() {
+	m.gate.Lock()
+	defer m.gate.Unlock()
+
+	m.lines = make([]string, 0)
+}
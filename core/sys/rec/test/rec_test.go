@@ -0,0 +1,181 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.ignitelabs.net/janos/core/sys/rec"
+)
+
+func Test_Rec_Threshold(t *testing.T) {
+	mem := rec.NewMemory()
+	rec.SetSinks(mem)
+	defer rec.SetSinks()
+
+	rec.Debugf("test", "hidden\n")
+	rec.Infof("test", "shown\n")
+	rec.Warnf("test", "warned\n")
+
+	lines := mem.Lines()
+	if len(lines) != 2 || lines[0] != "[test] shown" || lines[1] != "[test] warned" {
+		t.Fatalf("unexpected recordings: %v", lines)
+	}
+
+	// NOTE: atlas reloads the threshold from its own goroutine, so it must be safe to set while recording
+	defer rec.SetThreshold(rec.Threshold())
+	done := make(chan any)
+	go func() {
+		defer close(done)
+		rec.SetThreshold(slog.LevelWarn)
+	}()
+	rec.Infof("test", "racing\n")
+	<-done
+
+	rec.Infof("test", "hidden\n")
+	rec.Warnf("test", "raised\n")
+	if lines := mem.Lines(); lines[len(lines)-1] != "[test] raised" || strings.Contains(strings.Join(lines, "\n"), "hidden") {
+		t.Fatalf("unexpected recordings: %v", lines)
+	}
+}
+
+func Test_Rec_Override(t *testing.T) {
+	mem := rec.NewMemory()
+	rec.SetSinks(mem)
+	defer rec.SetSinks()
+	defer rec.SetLevels(nil)
+
+	rec.SetLevel("neuron", slog.LevelDebug)
+	rec.SetLevel("quiet", slog.LevelError)
+
+	rec.Debugf("cortex ⇝ neuron", "bridged\n")
+	rec.Debugf("cortex", "hidden\n")
+	rec.Warnf("cortex ⇝ quiet", "hidden\n")
+
+	lines := mem.Lines()
+	if len(lines) != 1 || lines[0] != "[cortex ⇝ neuron] bridged" {
+		t.Fatalf("unexpected recordings: %v", lines)
+	}
+}
+
+func Test_Rec_JSON(t *testing.T) {
+	mem := rec.NewMemory()
+	rec.SetSinks(mem)
+	defer rec.SetSinks()
+
+	rec.SetJSON(true)
+	defer rec.SetJSON(false)
+
+	rec.Logger("test").Info("structured", "key", 42)
+
+	out := mem.String()
+	for _, want := range []string{`"name":"test"`, `"msg":"structured"`, `"key":42`, `"level":"INFO"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %v in %v", want, out)
+		}
+	}
+}
+
+func Test_Rec_Fatal(t *testing.T) {
+	// NOTE: Fatal recordings exit the process, so each case re-runs this test in a child process
+	switch os.Getenv("REC_FATAL") {
+	case "memory":
+		rec.SetSinks(rec.NewMemory())
+		rec.FatalfCode(3, "test", "unrecoverable %d\n", 42)
+		return
+	case "silent":
+		rec.Silent = true
+		rec.Fatalf("test", "unrecoverable %d\n", 42)
+		return
+	case "stderr":
+		rec.SetSinks(os.Stderr)
+		rec.Fatalf("test", "unrecoverable %d\n", 42)
+		return
+	}
+
+	for mode, code := range map[string]int{"memory": 3, "silent": 1, "stderr": 1} {
+		cmd := exec.Command(os.Args[0], "-test.run=^Test_Rec_Fatal$")
+		cmd.Env = append(os.Environ(), "REC_FATAL="+mode)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+
+		var exited *exec.ExitError
+		if !errors.As(err, &exited) || exited.ExitCode() != code {
+			t.Errorf("%s: expected exit code %d, got %v", mode, code, err)
+		}
+		if count := strings.Count(stderr.String(), "[test] unrecoverable 42"); count != 1 {
+			t.Errorf("%s: expected the recording to reach stderr exactly once, got %q", mode, stderr.String())
+		}
+	}
+}
+
+func Test_Rec_RotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "janos.log")
+	file, err := rec.NewRotatingFile(path, 16, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.SetSinks(file)
+	defer rec.SetSinks()
+
+	for _, generation := range []string{"first", "second", "third", "fourth"} {
+		rec.Infof("test", "%s\n", generation)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for suffix, want := range map[string]string{"": "[test] fourth\n", ".1": "[test] third\n", ".2": "[test] second\n"} {
+		data, err := os.ReadFile(path + suffix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("expected %q in janos.log%s, got %q", want, suffix, data)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only 2 backups to be retained, got %v", err)
+	}
+	if _, err := file.Write([]byte("late\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected writing to a closed file to fail, got %v", err)
+	}
+}
+
+func Test_Rec_RotatingFile_Failure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "janos.log")
+	file, err := rec.NewRotatingFile(path, 16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// NOTE: A non-empty directory in place of the first generation can be neither removed nor renamed over
+	if err := os.MkdirAll(filepath.Join(path+".1", "blocked"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := file.Write([]byte("first line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("second line\n")); err == nil {
+		t.Error("expected the failed rotation to be reported")
+	}
+	if _, err := file.Write([]byte("third line\n")); errors.Is(err, os.ErrClosed) {
+		t.Fatal("expected the sink to keep recording after a failed rotation")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "first line\nsecond line\nthird line\n"; string(data) != want {
+		t.Errorf("expected %q, got %q", want, data)
+	}
+}
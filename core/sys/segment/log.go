@@ -0,0 +1,336 @@
+// Package segment provides an append-only, segmented log of timestamped records - the storage beneath persistent
+// temporal buffers.
+//
+// Each segment is a file of consecutive frames laid out as:
+//
+//	[length uint32][checksum uint32][moment int64][payload...]
+//
+// all little-endian, where the checksum is the CRC-32 (IEEE) of the moment and payload.  A frame which is cut short or
+// fails its checksum marks the end of a segment - on Open, the newest segment is truncated back to its last intact
+// frame, so a crash mid-write only ever loses the record being written.
+package segment
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	headerSize = 16
+	extension  = ".seg"
+)
+
+// ErrClosed is returned when appending to a Log which has been closed.
+var ErrClosed = errors.New("segment log closed")
+
+// Options defines how a Log rotates and retains its segments.
+type Options struct {
+	// SegmentBytes is the size a segment may grow to before a new one is started - defaulting to 4MiB.
+	SegmentBytes int64
+
+	// MaxAge removes any segment whose newest record is older than this, relative to the newest recorded moment - zero retains forever.
+	MaxAge time.Duration
+
+	// MaxBytes removes the oldest segments while the log is larger than this - zero retains without limit.
+	MaxBytes int64
+
+	// Sync flushes every append to stable storage before returning, trading throughput for durability against power loss.
+	Sync bool
+}
+
+func (o Options) segmentBytes() int64 {
+	if o.SegmentBytes <= 0 {
+		return 4 << 20
+	}
+	return o.SegmentBytes
+}
+
+// A Log is an append-only series of segment files within a single directory.  It's safe for concurrent use.
+type Log struct {
+	Options
+
+	dir      string
+	segments []*info
+	active   *os.File
+	closed   bool
+	master   sync.Mutex
+}
+
+// info describes a single segment file.
+type info struct {
+	sequence uint64
+	size     int64
+	newest   time.Time
+}
+
+// Open opens (or creates) the log within the provided directory, recovering from any torn write left by a crash.
+func Open(dir string, options ...Options) (*Log, error) {
+	var o Options
+	if len(options) > 0 {
+		o = options[0]
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{Options: o, dir: dir}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, extension) {
+			continue
+		}
+		sequence, err := strconv.ParseUint(strings.TrimSuffix(name, extension), 16, 64)
+		if err != nil {
+			continue
+		}
+		l.segments = append(l.segments, &info{sequence: sequence})
+	}
+	sort.Slice(l.segments, func(i, j int) bool {
+		return l.segments[i].sequence < l.segments[j].sequence
+	})
+
+	for i, s := range l.segments {
+		intact, err := l.scan(s, nil)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(l.segments)-1 && intact < s.size {
+			if err := os.Truncate(l.path(s), intact); err != nil {
+				return nil, err
+			}
+			s.size = intact
+		}
+	}
+
+	if len(l.segments) == 0 {
+		l.segments = append(l.segments, &info{})
+	}
+	if err := l.activate(l.segments[len(l.segments)-1]); err != nil {
+		return nil, err
+	}
+	return l, l.enforce()
+}
+
+// Dir returns the directory the log is stored within.
+func (l *Log) Dir() string {
+	return l.dir
+}
+
+// Append writes a record to the end of the log, rotating to a new segment and enforcing retention when the active
+// segment grows beyond SegmentBytes.
+func (l *Log) Append(moment time.Time, payload []byte) error {
+	l.master.Lock()
+	defer l.master.Unlock()
+
+	if l.closed {
+		return ErrClosed
+	}
+
+	frame := make([]byte, headerSize+len(payload))
+	binary.LittleEndian.PutUint32(frame[0:], uint32(len(payload)))
+	binary.LittleEndian.PutUint64(frame[8:], uint64(moment.UnixNano()))
+	copy(frame[headerSize:], payload)
+	binary.LittleEndian.PutUint32(frame[4:], crc32.ChecksumIEEE(frame[8:]))
+
+	// NOTE: The frame is written in a single call, so a crash can only ever tear the final frame
+	if _, err := l.active.Write(frame); err != nil {
+		return err
+	}
+	if l.Sync {
+		if err := l.active.Sync(); err != nil {
+			return err
+		}
+	}
+
+	current := l.segments[len(l.segments)-1]
+	current.size += int64(len(frame))
+	if moment.After(current.newest) {
+		current.newest = moment
+	}
+
+	if current.size >= l.segmentBytes() {
+		next := &info{sequence: current.sequence + 1}
+		if err := l.activate(next); err != nil {
+			return err
+		}
+		l.segments = append(l.segments, next)
+		return l.enforce()
+	}
+	return nil
+}
+
+// Replay calls fn with every intact record in the log, oldest segment first, skipping any recorded before the
+// provided moment.  If fn returns an error, the replay stops and returns it.
+func (l *Log) Replay(since time.Time, fn func(moment time.Time, payload []byte) error) error {
+	l.master.Lock()
+	defer l.master.Unlock()
+
+	for _, s := range l.segments {
+		if s.newest.Before(since) {
+			continue
+		}
+		if _, err := l.scan(s, func(moment time.Time, payload []byte) error {
+			if moment.Before(since) {
+				return nil
+			}
+			return fn(moment, payload)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Size returns the total number of bytes held across every segment.
+func (l *Log) Size() int64 {
+	l.master.Lock()
+	defer l.master.Unlock()
+
+	var total int64
+	for _, s := range l.segments {
+		total += s.size
+	}
+	return total
+}
+
+// Segments returns the number of segment files currently held.
+func (l *Log) Segments() int {
+	l.master.Lock()
+	defer l.master.Unlock()
+
+	return len(l.segments)
+}
+
+// Close flushes and closes the active segment - any further appends return ErrClosed.
+func (l *Log) Close() error {
+	l.master.Lock()
+	defer l.master.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if err := l.active.Sync(); err != nil {
+		_ = l.active.Close()
+		return err
+	}
+	return l.active.Close()
+}
+
+func (l *Log) path(s *info) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%016x%s", s.sequence, extension))
+}
+
+// activate closes the current segment, if any, and opens the provided one for appending.
+func (l *Log) activate(s *info) error {
+	if l.active != nil {
+		if err := l.active.Close(); err != nil {
+			return err
+		}
+	}
+	file, err := os.OpenFile(l.path(s), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	l.active = file
+	return nil
+}
+
+// enforce removes every segment, other than the active one, which falls outside of the retention options.
+func (l *Log) enforce() error {
+	var newest time.Time
+	var total int64
+	for _, s := range l.segments {
+		if s.newest.After(newest) {
+			newest = s.newest
+		}
+		total += s.size
+	}
+
+	var keep int
+	for keep < len(l.segments)-1 {
+		s := l.segments[keep]
+		aged := l.MaxAge > 0 && newest.Sub(s.newest) > l.MaxAge
+		oversized := l.MaxBytes > 0 && total > l.MaxBytes
+		if !aged && !oversized {
+			break
+		}
+		if err := os.Remove(l.path(s)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		total -= s.size
+		keep++
+	}
+	l.segments = l.segments[keep:]
+	return nil
+}
+
+// scan reads every intact frame of the provided segment, refreshing its size and newest moment and returning the
+// offset just past the last intact frame.  If fn is provided, it's called with each record.
+func (l *Log) scan(s *info, fn func(time.Time, []byte) error) (int64, error) {
+	file, err := os.Open(l.path(s))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	s.size = stat.Size()
+
+	var offset int64
+	header := make([]byte, headerSize)
+	for {
+		if _, err := io.ReadFull(file, header); err != nil {
+			return offset, nil
+		}
+		length := int64(binary.LittleEndian.Uint32(header[0:]))
+		if offset+headerSize+length > s.size {
+			return offset, nil
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(file, payload); err != nil {
+			return offset, nil
+		}
+		checksum := crc32.NewIEEE()
+		_, _ = checksum.Write(header[8:])
+		_, _ = checksum.Write(payload)
+		if checksum.Sum32() != binary.LittleEndian.Uint32(header[4:]) {
+			return offset, nil
+		}
+
+		moment := time.Unix(0, int64(binary.LittleEndian.Uint64(header[8:])))
+		if moment.After(s.newest) {
+			s.newest = moment
+		}
+		offset += headerSize + length
+
+		if fn != nil {
+			if err := fn(moment, payload); err != nil {
+				return offset, err
+			}
+		}
+	}
+}
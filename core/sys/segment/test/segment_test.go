@@ -0,0 +1,87 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/sys/segment"
+)
+
+func replay(t *testing.T, log *segment.Log, since time.Time) []string {
+	t.Helper()
+	var out []string
+	err := log.Replay(since, func(moment time.Time, payload []byte) error {
+		out = append(out, string(payload))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func Test_Log_Recovery(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Unix(1000, 0)
+
+	log, err := segment.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := log.Append(start.Add(time.Duration(i)*time.Second), []byte(fmt.Sprint(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = log.Close()
+
+	// Simulate a crash partway through writing a fourth frame
+	files, _ := filepath.Glob(filepath.Join(dir, "*.seg"))
+	file, _ := os.OpenFile(files[len(files)-1], os.O_WRONLY|os.O_APPEND, 0o644)
+	_, _ = file.Write([]byte{9, 0, 0, 0, 1, 2})
+	_ = file.Close()
+
+	log, err = segment.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+	_ = log.Append(start.Add(3*time.Second), []byte("3"))
+
+	got := replay(t, log, start.Add(time.Second))
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+}
+
+func Test_Log_Retention(t *testing.T) {
+	start := time.Unix(1000, 0)
+	log, err := segment.Open(t.TempDir(), segment.Options{SegmentBytes: 1, MaxAge: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+
+	for i := 0; i < 10; i++ {
+		_ = log.Append(start.Add(time.Duration(i)*time.Second), []byte(fmt.Sprint(i)))
+	}
+	got := replay(t, log, time.Time{})
+	if fmt.Sprint(got) != "[4 5 6 7 8 9]" {
+		t.Errorf("expected the last 5 seconds to be retained, got %v", got)
+	}
+
+	sized, err := segment.Open(t.TempDir(), segment.Options{SegmentBytes: 1, MaxBytes: 3 * 17})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sized.Close()
+	for i := 0; i < 10; i++ {
+		_ = sized.Append(start, []byte(fmt.Sprint(i)))
+	}
+	if sized.Size() > 3*17 {
+		t.Errorf("expected at most %d bytes, got %d", 3*17, sized.Size())
+	}
+}
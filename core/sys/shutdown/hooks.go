@@ -0,0 +1,130 @@
+// Package shutdown provides the phased, deadline-bounded hook system used to shut down both JanOS instances and
+// individual cortices.
+package shutdown
+
+import (
+	"sync"
+	"time"
+)
+
+// A Hook is a named action which runs during a shutdown Phase.  Just like a deferral, the action must call wg.Done()
+// once it has finished - a hook which doesn't do so before the deadline is reported by name.
+type Hook struct {
+	Named  string
+	Action func(wg *sync.WaitGroup)
+
+	id uint64
+}
+
+// Hooks holds the hooks registered against each Phase.  The zero value is ready to use.
+type Hooks struct {
+	hooks map[Phase][]Hook
+	next  uint64
+	gate  sync.Mutex
+}
+
+// Add registers a named action to run during the provided phase, returning a function which unregisters it.
+//
+// NOTE: Unregistering a hook which has already been taken does nothing.
+func (h *Hooks) Add(phase Phase, named string, action func(*sync.WaitGroup)) (remove func()) {
+	h.gate.Lock()
+	defer h.gate.Unlock()
+
+	if h.hooks == nil {
+		h.hooks = make(map[Phase][]Hook)
+	}
+	h.next++
+	id := h.next
+	h.hooks[phase] = append(h.hooks[phase], Hook{Named: named, Action: action, id: id})
+
+	return func() {
+		h.gate.Lock()
+		defer h.gate.Unlock()
+
+		hooks := h.hooks[phase]
+		for i, hook := range hooks {
+			if hook.id == id {
+				h.hooks[phase] = append(hooks[:i:i], hooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Take removes and returns every hook registered against the provided phase.
+func (h *Hooks) Take(phase Phase) []Hook {
+	h.gate.Lock()
+	defer h.gate.Unlock()
+
+	hooks := h.hooks[phase]
+	delete(h.hooks, phase)
+	return hooks
+}
+
+// Await concurrently runs every provided hook and blocks until they have all finished or the deadline passes, returning
+// the names of any which didn't finish in time.  A hook which panics is considered finished, and its panic is provided
+// to onPanic.
+//
+// NOTE: A zero deadline waits indefinitely.
+func Await(deadline time.Time, onPanic func(named string, r any), hooks ...Hook) []string {
+	done := make([]chan any, len(hooks))
+	for i, hook := range hooks {
+		done[i] = make(chan any)
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+
+		go func() {
+			wg.Wait()
+			close(done[i])
+		}()
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					if onPanic != nil {
+						onPanic(hook.Named, r)
+					}
+					wg.Done()
+				}
+			}()
+
+			hook.Action(wg)
+		}()
+	}
+
+	var expired <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	var unfinished []string
+	for i := range hooks {
+		select {
+		case <-done[i]:
+			continue
+		case <-expired:
+		}
+
+		// NOTE: Once the deadline has passed, only report the stragglers
+		for ; i < len(hooks); i++ {
+			select {
+			case <-done[i]:
+			default:
+				unfinished = append(unfinished, hooks[i].Named)
+			}
+		}
+		break
+	}
+	return unfinished
+}
+
+// Deadline calculates the deadline for a shutdown beginning now which is bounded by the provided timeout.
+//
+// NOTE: A timeout of zero or less results in a zero deadline, which waits indefinitely.
+func Deadline(timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
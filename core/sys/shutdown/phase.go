@@ -0,0 +1,38 @@
+package shutdown
+
+// A Phase defines a step of an ordered shutdown.  Every phase completes (or times out) before the next begins:
+//
+// 0 - Quiesce - new activity stops being accepted
+//
+// 1 - Drain - in-flight activity is allowed to finish
+//
+// 2 - Cleanup - deferrals release any held resources
+//
+// 3 - Exit - final actions just before the shutdown completes
+type Phase byte
+
+const (
+	Quiesce Phase = iota
+	Drain
+	Cleanup
+	Exit
+)
+
+// Phases holds every phase in the order they run.
+var Phases = []Phase{Quiesce, Drain, Cleanup, Exit}
+
+// String prints an uppercase one-word representation of the Phase.
+func (p Phase) String() string {
+	switch p {
+	case Quiesce:
+		return "Quiesce"
+	case Drain:
+		return "Drain"
+	case Cleanup:
+		return "Cleanup"
+	case Exit:
+		return "Exit"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"sync"
+	"testing"
+
+	"git.ignitelabs.net/janos/core/sys/shutdown"
+)
+
+func Test_Hooks_Remove(t *testing.T) {
+	var hooks shutdown.Hooks
+	noop := func(wg *sync.WaitGroup) { wg.Done() }
+
+	hooks.Add(shutdown.Drain, "first", noop)
+	remove := hooks.Add(shutdown.Drain, "second", noop)
+	hooks.Add(shutdown.Drain, "third", noop)
+
+	remove()
+	remove()
+
+	taken := hooks.Take(shutdown.Drain)
+	if len(taken) != 2 || taken[0].Named != "first" || taken[1].Named != "third" {
+		t.Fatalf("expected only the first and third hooks to remain, got %v", taken)
+	}
+
+	// NOTE: Removing a hook which has already been taken must not disturb later registrations
+	late := hooks.Add(shutdown.Drain, "late", noop)
+	remove()
+	if taken := hooks.Take(shutdown.Drain); len(taken) != 1 || taken[0].Named != "late" {
+		t.Fatalf("expected the late hook to remain, got %v", taken)
+	}
+	late()
+}
@@ -0,0 +1,70 @@
+//go:build !linux
+
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/change"
+)
+
+// entry records what a snapshot knows of a single path.
+type entry struct {
+	modified time.Time
+	size     int64
+	dir      bool
+}
+
+// snapshot is the observed state of a watched directory, used by backends which can only learn that something
+// changed - not what.
+type snapshot map[string]entry
+
+// scan records the directory - and, if recursive, every directory beneath it.
+func scan(dir string, recursive bool) snapshot {
+	s := make(snapshot)
+	record := func(path string, info fs.FileInfo) {
+		s[path] = entry{modified: info.ModTime(), size: info.Size(), dir: info.IsDir()}
+	}
+
+	if !recursive {
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if info, err := e.Info(); err == nil {
+				record(filepath.Join(dir, e.Name()), info)
+			}
+		}
+		return s
+	}
+
+	_ = filepath.WalkDir(dir, func(path string, e fs.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return nil
+		}
+		if info, err := e.Info(); err == nil {
+			record(path, info)
+		}
+		return nil
+	})
+	return s
+}
+
+// diff reports every difference between the prior snapshot and this one.
+func (s snapshot) diff(prior snapshot, emit func(Event)) {
+	for path := range prior {
+		if _, ok := s[path]; !ok {
+			emit(Event{Path: path, Change: change.Delete})
+		}
+	}
+	for path, now := range s {
+		before, ok := prior[path]
+		switch {
+		case !ok:
+			emit(Event{Path: path, Change: change.Create})
+		case !now.dir && (!now.modified.Equal(before.modified) || now.size != before.size):
+			emit(Event{Path: path, Change: change.Modify})
+		}
+	}
+}
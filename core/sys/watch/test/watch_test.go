@@ -0,0 +1,211 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/change"
+	"git.ignitelabs.net/janos/core/sys/watch"
+)
+
+// expect waits for an event matching the path and change, failing if none arrives in time.
+func expect(t *testing.T, w *watch.Watcher, path string, kind change.Kind) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e, ok := <-w.Events():
+			if !ok {
+				t.Fatalf("events closed while waiting for %v %v", kind, path)
+			}
+			if e.Path == path && e.Change == kind {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("never observed %v %v", kind, path)
+		}
+	}
+}
+
+func open(t *testing.T, path string, options ...watch.Options) *watch.Watcher {
+	t.Helper()
+	w, err := watch.New(path, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = w.Close()
+	})
+	return w
+}
+
+func write(t *testing.T, path string, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Watch_Changes(t *testing.T) {
+	dir := t.TempDir()
+	w := open(t, dir)
+	path := filepath.Join(dir, "file")
+
+	write(t, path, "a")
+	expect(t, w, path, change.Create)
+
+	// NOTE: Snapshot backends compare modification times, so the write must land in a later instant
+	time.Sleep(10 * time.Millisecond)
+	write(t, path, "ab")
+	expect(t, w, path, change.Modify)
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, w, path, change.Delete)
+}
+
+func Test_Watch_Rename(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "from")
+	to := filepath.Join(dir, "to")
+	write(t, from, "a")
+
+	w := open(t, dir)
+	if err := os.Rename(from, to); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, w, from, change.Rename)
+	expect(t, w, to, change.Create)
+}
+
+func Test_Watch_File(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watched")
+	w := open(t, path)
+
+	// Changes to neighbouring files shouldn't be reported
+	write(t, filepath.Join(dir, "other"), "x")
+	write(t, path, "a")
+
+	e := <-w.Events()
+	if e.Path != path || e.Change != change.Create {
+		t.Fatalf("expected the watched file's creation, got %v %v", e.Change, e.Path)
+	}
+}
+
+func Test_Watch_Recursive(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	w := open(t, dir, watch.Options{Recursive: true})
+
+	existing := filepath.Join(nested, "existing")
+	write(t, existing, "a")
+	expect(t, w, existing, change.Create)
+
+	// Directories created after the watch began are watched as well
+	created := filepath.Join(dir, "c")
+	if err := os.Mkdir(created, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, w, created, change.Create)
+
+	later := filepath.Join(created, "later")
+	write(t, later, "a")
+	expect(t, w, later, change.Create)
+}
+
+func Test_Watch_NotRecursive(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	if err := os.Mkdir(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	w := open(t, dir)
+	write(t, filepath.Join(nested, "ignored"), "a")
+	marker := filepath.Join(dir, "marker")
+	write(t, marker, "a")
+
+	for e := range w.Events() {
+		if e.Path == marker {
+			return
+		}
+		if filepath.Dir(e.Path) == nested {
+			t.Fatalf("observed %v beneath a directory which isn't watched", e.Path)
+		}
+	}
+}
+
+func Test_Watch_Debounce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	w := open(t, dir, watch.Options{Debounce: 100 * time.Millisecond})
+
+	start := time.Now()
+	write(t, path, "a")
+	for i := 0; i < 10; i++ {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = f.WriteString("b")
+		_ = f.Close()
+	}
+
+	var events []watch.Event
+	quiet := time.After(500 * time.Millisecond)
+collect:
+	for {
+		select {
+		case e := <-w.Events():
+			if len(events) == 0 && time.Since(start) < 100*time.Millisecond {
+				t.Fatal("events were delivered before the watch was quiet")
+			}
+			events = append(events, e)
+		case <-quiet:
+			break collect
+		}
+	}
+
+	seen := make(map[watch.Event]bool)
+	for _, e := range events {
+		if seen[e] {
+			t.Fatalf("duplicate event %v %v was delivered", e.Change, e.Path)
+		}
+		seen[e] = true
+	}
+	if !seen[watch.Event{Path: path, Change: change.Create}] {
+		t.Fatalf("expected the creation to be delivered, got %v", events)
+	}
+}
+
+func Test_Watch_Close(t *testing.T) {
+	dir := t.TempDir()
+	first := open(t, dir)
+	second := open(t, dir)
+
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-first.Events(); ok {
+		t.Fatal("events should be closed once the watcher is")
+	}
+	if first.Err() != watch.ErrClosed {
+		t.Fatalf("expected ErrClosed, got %v", first.Err())
+	}
+
+	// Closing one watcher leaves every other watching
+	path := filepath.Join(dir, "file")
+	write(t, path, "a")
+	expect(t, second, path, change.Create)
+	if second.Err() != nil {
+		t.Fatalf("expected the second watcher to still be running, got %v", second.Err())
+	}
+}
@@ -0,0 +1,214 @@
+// Package watch provides file system watchers - observing a file, a directory, or an entire directory tree, and
+// reporting each change as a typed Event.
+//
+// On Linux, watchers are backed by inotify and block in epoll until there's something to report.  On macOS and the
+// BSDs they're backed by kqueue, and on Windows by directory change notifications - in both cases, the watched tree is
+// rescanned whenever the system reports a change, and the differences are reported as events.
+//
+// NOTE: Only Linux can observe a rename directly - elsewhere, a rename is reported as a Delete and a Create.
+package watch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/change"
+)
+
+// An Event describes a single change to a path within the watch.
+type Event struct {
+	Path   string
+	Change change.Kind
+}
+
+// Options defines how a Watcher observes its path.
+type Options struct {
+	// Recursive observes every directory beneath a watched directory, including those created after the watch began.
+	Recursive bool
+
+	// Debounce holds events until the watch has been quiet for this long, then delivers them with any duplicates
+	// removed - useful when a single save produces a burst of writes.  Zero delivers every event as it happens.
+	Debounce time.Duration
+}
+
+// ErrClosed is reported by Err once a Watcher has been closed.
+var ErrClosed = errors.New("watcher closed")
+
+// A Watcher observes a file or directory until it's closed.  See New
+type Watcher struct {
+	Options
+
+	path    string
+	file    string // NOTE: file is set when watching a single file, whose directory is then watched in its place
+	events  chan Event
+	backend backend
+
+	err      error
+	stopping chan any
+	done     chan any
+	closing  sync.Once
+	gate     sync.Mutex
+}
+
+// backend is a platform's means of observing a directory.
+type backend interface {
+	// run reports changes until the backend is closed, returning any error which stopped it early.
+	run(emit func(Event)) error
+
+	// close stops the backend and unblocks run.
+	close() error
+}
+
+// New begins watching the provided path.  If the path is a file - or doesn't yet exist - its directory is watched in
+// its place, and only the events concerning the file itself are reported.  This allows a file to be observed even as
+// editors replace it, or before it's been created.
+func New(path string, options ...Options) (*Watcher, error) {
+	var o Options
+	if len(options) > 0 {
+		o = options[0]
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		Options:  o,
+		path:     path,
+		events:   make(chan Event, 64),
+		stopping: make(chan any),
+		done:     make(chan any),
+	}
+
+	dir := path
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		dir = filepath.Dir(path)
+		w.file = path
+		o.Recursive = false
+	}
+
+	w.backend, err = newBackend(dir, o.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(chan Event, 64)
+	go func() {
+		defer close(raw)
+		err := w.backend.run(func(e Event) {
+			if w.file == "" || e.Path == w.file {
+				raw <- e
+			}
+		})
+		w.gate.Lock()
+		if w.err == nil {
+			w.err = err
+		}
+		w.gate.Unlock()
+	}()
+	go w.deliver(raw)
+	return w, nil
+}
+
+// OnChange watches the provided path, calling fn with every event until the returned Watcher is closed.
+func OnChange(path string, fn func(Event), options ...Options) (*Watcher, error) {
+	w, err := New(path, options...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for e := range w.Events() {
+			fn(e)
+		}
+	}()
+	return w, nil
+}
+
+// Path returns the absolute path being watched.
+func (w *Watcher) Path() string {
+	return w.path
+}
+
+// Events returns the channel events are delivered on - it's closed once the watcher stops.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Err returns the reason the watcher stopped - ErrClosed if it was closed, or nil while it's still watching.
+func (w *Watcher) Err() error {
+	w.gate.Lock()
+	defer w.gate.Unlock()
+
+	return w.err
+}
+
+// Close stops the watcher and closes its events channel.  Closing a watcher affects no other watcher.
+func (w *Watcher) Close() error {
+	var err error
+	w.closing.Do(func() {
+		w.gate.Lock()
+		if w.err == nil {
+			w.err = ErrClosed
+		}
+		w.gate.Unlock()
+
+		close(w.stopping)
+		err = w.backend.close()
+		<-w.done
+	})
+	return err
+}
+
+// deliver forwards raw events to the events channel, debouncing them if requested.
+func (w *Watcher) deliver(raw <-chan Event) {
+	defer close(w.done)
+	defer close(w.events)
+
+	// NOTE: Once closing, events are dropped rather than delivered - but raw is drained until the backend stops
+	send := func(e Event) {
+		select {
+		case w.events <- e:
+		case <-w.stopping:
+		}
+	}
+
+	if w.Debounce <= 0 {
+		for e := range raw {
+			send(e)
+		}
+		return
+	}
+
+	var pending []Event
+	seen := make(map[Event]struct{})
+	var quiet <-chan time.Time
+	flush := func() {
+		for _, e := range pending {
+			send(e)
+		}
+		pending = pending[:0]
+		clear(seen)
+	}
+
+	for {
+		select {
+		case e, ok := <-raw:
+			if !ok {
+				flush()
+				return
+			}
+			if _, ok := seen[e]; !ok {
+				seen[e] = struct{}{}
+				pending = append(pending, e)
+			}
+			quiet = time.After(w.Debounce)
+		case <-quiet:
+			quiet = nil
+			flush()
+		}
+	}
+}
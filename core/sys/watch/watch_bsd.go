@@ -0,0 +1,130 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package watch
+
+import (
+	"os"
+	"syscall"
+)
+
+// kqueue observes a directory through kqueue vnode events, blocking until either an event or the close signal
+// arrives.  As kqueue reports only that a path changed, the tree is rescanned and the differences reported.
+type kqueue struct {
+	dir       string
+	recursive bool
+	kq        int
+	wake      [2]int // NOTE: wake is a pipe - writing to it unblocks kevent when closing
+	files     map[string]int
+	state     snapshot
+}
+
+func newBackend(dir string, recursive bool) (backend, error) {
+	kq, err := syscall.Kqueue()
+	if err != nil {
+		return nil, err
+	}
+	b := &kqueue{dir: dir, recursive: recursive, kq: kq, wake: [2]int{-1, -1}, files: make(map[string]int)}
+
+	if err = syscall.Pipe(b.wake[:]); err != nil {
+		b.release()
+		return nil, err
+	}
+	syscall.CloseOnExec(b.wake[0])
+	syscall.CloseOnExec(b.wake[1])
+
+	var change syscall.Kevent_t
+	syscall.SetKevent(&change, b.wake[0], syscall.EVFILT_READ, syscall.EV_ADD)
+	if _, err = syscall.Kevent(b.kq, []syscall.Kevent_t{change}, nil, nil); err != nil {
+		b.release()
+		return nil, err
+	}
+	if err = b.open(dir); err != nil {
+		b.release()
+		return nil, err
+	}
+
+	b.state = scan(dir, recursive)
+	b.sync()
+	return b, nil
+}
+
+// open registers a path for vnode events.
+func (b *kqueue) open(path string) error {
+	fd, err := syscall.Open(path, syscall.O_RDONLY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+
+	var change syscall.Kevent_t
+	syscall.SetKevent(&change, fd, syscall.EVFILT_VNODE, syscall.EV_ADD|syscall.EV_CLEAR)
+	change.Fflags = syscall.NOTE_WRITE | syscall.NOTE_DELETE | syscall.NOTE_EXTEND | syscall.NOTE_ATTRIB |
+		syscall.NOTE_RENAME | syscall.NOTE_REVOKE
+	if _, err = syscall.Kevent(b.kq, []syscall.Kevent_t{change}, nil, nil); err != nil {
+		_ = syscall.Close(fd)
+		return err
+	}
+	b.files[path] = fd
+	return nil
+}
+
+// sync registers every path in the current snapshot, and releases those which have gone.
+func (b *kqueue) sync() {
+	for path, fd := range b.files {
+		if _, ok := b.state[path]; !ok && path != b.dir {
+			_ = syscall.Close(fd)
+			delete(b.files, path)
+		}
+	}
+	for path := range b.state {
+		if _, ok := b.files[path]; !ok {
+			_ = b.open(path)
+		}
+	}
+}
+
+func (b *kqueue) run(emit func(Event)) error {
+	defer b.release()
+
+	events := make([]syscall.Kevent_t, 64)
+	for {
+		n, err := syscall.Kevent(b.kq, nil, events, nil)
+		if err != nil {
+			if err == syscall.EINTR {
+				continue
+			}
+			return err
+		}
+
+		for _, event := range events[:n] {
+			if int(event.Ident) == b.wake[0] {
+				return nil
+			}
+		}
+
+		if _, err := os.Stat(b.dir); err != nil {
+			return err
+		}
+		state := scan(b.dir, b.recursive)
+		state.diff(b.state, emit)
+		b.state = state
+		b.sync()
+	}
+}
+
+func (b *kqueue) close() error {
+	// NOTE: If run has already stopped, the pipe is closed and the write harmlessly fails
+	_, _ = syscall.Write(b.wake[1], []byte{0})
+	return syscall.Close(b.wake[1])
+}
+
+// release closes every descriptor run reads from - the pipe's write end belongs to close.
+func (b *kqueue) release() {
+	for _, fd := range b.files {
+		_ = syscall.Close(fd)
+	}
+	for _, fd := range []int{b.kq, b.wake[0]} {
+		if fd >= 0 {
+			_ = syscall.Close(fd)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+//go:build linux
+
+package watch
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"git.ignitelabs.net/janos/core/enum/change"
+)
+
+const inotifyMask = syscall.IN_CREATE | syscall.IN_MODIFY | syscall.IN_CLOSE_WRITE | syscall.IN_DELETE |
+	syscall.IN_DELETE_SELF | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_MOVE_SELF
+
+// inotify observes directories through inotify, blocking in epoll until either an event or the close signal arrives.
+type inotify struct {
+	recursive bool
+	fd        int
+	epoll     int
+	wake      [2]int // NOTE: wake is a pipe - writing to it unblocks epoll when closing
+	dirs      map[int]string
+}
+
+func newBackend(dir string, recursive bool) (backend, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	b := &inotify{recursive: recursive, fd: fd, epoll: -1, wake: [2]int{-1, -1}, dirs: make(map[int]string)}
+
+	fail := func(err error) (backend, error) {
+		b.release()
+		return nil, err
+	}
+
+	if b.epoll, err = syscall.EpollCreate1(syscall.EPOLL_CLOEXEC); err != nil {
+		return fail(err)
+	}
+	if err = syscall.Pipe2(b.wake[:], syscall.O_CLOEXEC|syscall.O_NONBLOCK); err != nil {
+		return fail(err)
+	}
+	for _, watched := range []int{b.fd, b.wake[0]} {
+		event := syscall.EpollEvent{Events: syscall.EPOLLIN, Fd: int32(watched)}
+		if err = syscall.EpollCtl(b.epoll, syscall.EPOLL_CTL_ADD, watched, &event); err != nil {
+			return fail(err)
+		}
+	}
+	if err = b.add(dir, nil); err != nil {
+		return fail(err)
+	}
+	return b, nil
+}
+
+// add watches the directory - and, if recursive, every directory beneath it.  If emit is provided, every path found
+// beneath the directory is reported as created, as they may have appeared before the watch began.
+func (b *inotify) add(dir string, emit func(Event)) error {
+	if !b.recursive {
+		wd, err := syscall.InotifyAddWatch(b.fd, dir, inotifyMask)
+		if err != nil {
+			return err
+		}
+		b.dirs[wd] = dir
+		return nil
+	}
+
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// NOTE: A directory may vanish between being reported and being walked
+			if path != dir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if emit != nil && path != dir {
+			emit(Event{Path: path, Change: change.Create})
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		wd, err := syscall.InotifyAddWatch(b.fd, path, inotifyMask)
+		if err != nil {
+			if path != dir {
+				return nil
+			}
+			return err
+		}
+		b.dirs[wd] = path
+		return nil
+	})
+}
+
+func (b *inotify) run(emit func(Event)) error {
+	defer b.release()
+
+	events := make([]syscall.EpollEvent, 2)
+	buffer := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := syscall.EpollWait(b.epoll, events, -1)
+		if err != nil {
+			if err == syscall.EINTR {
+				continue
+			}
+			return err
+		}
+
+		for _, event := range events[:n] {
+			if int(event.Fd) == b.wake[0] {
+				return nil
+			}
+		}
+		if err = b.read(buffer, emit); err != nil {
+			return err
+		}
+	}
+}
+
+// read drains every pending inotify event, reporting each one.
+func (b *inotify) read(buffer []byte, emit func(Event)) error {
+	for {
+		n, err := syscall.Read(b.fd, buffer)
+		if err == syscall.EAGAIN || err == syscall.EINTR {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			raw := (*syscall.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+			name := buffer[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(raw.Len)]
+			offset += syscall.SizeofInotifyEvent + int(raw.Len)
+
+			dir, ok := b.dirs[int(raw.Wd)]
+			if !ok {
+				continue
+			}
+			if raw.Mask&syscall.IN_IGNORED != 0 {
+				delete(b.dirs, int(raw.Wd))
+				continue
+			}
+
+			path := dir
+			if end := indexZero(name); end > 0 {
+				path = filepath.Join(dir, string(name[:end]))
+			}
+			isDir := raw.Mask&syscall.IN_ISDIR != 0
+
+			switch {
+			case raw.Mask&(syscall.IN_DELETE_SELF|syscall.IN_MOVE_SELF) != 0:
+				// NOTE: The directory's own removal is reported by its parent - unless it's the root of the watch
+				if !b.watching(filepath.Dir(dir)) {
+					if raw.Mask&syscall.IN_DELETE_SELF != 0 {
+						emit(Event{Path: dir, Change: change.Delete})
+					} else {
+						emit(Event{Path: dir, Change: change.Rename})
+					}
+				}
+			case raw.Mask&syscall.IN_CREATE != 0, raw.Mask&syscall.IN_MOVED_TO != 0:
+				emit(Event{Path: path, Change: change.Create})
+				if isDir && b.recursive {
+					_ = b.add(path, emit)
+				}
+			case raw.Mask&syscall.IN_DELETE != 0:
+				emit(Event{Path: path, Change: change.Delete})
+			case raw.Mask&syscall.IN_MOVED_FROM != 0:
+				emit(Event{Path: path, Change: change.Rename})
+			case raw.Mask&(syscall.IN_MODIFY|syscall.IN_CLOSE_WRITE) != 0:
+				if !isDir {
+					emit(Event{Path: path, Change: change.Modify})
+				}
+			}
+		}
+	}
+}
+
+// watching reports whether the directory is currently being watched.
+func (b *inotify) watching(dir string) bool {
+	for _, watched := range b.dirs {
+		if watched == dir {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *inotify) close() error {
+	// NOTE: If run has already stopped, the pipe is closed and the write harmlessly fails
+	_, _ = syscall.Write(b.wake[1], []byte{0})
+	return syscall.Close(b.wake[1])
+}
+
+// release closes every descriptor run reads from - the pipe's write end belongs to close.
+func (b *inotify) release() {
+	for _, fd := range []int{b.fd, b.epoll, b.wake[0]} {
+		if fd >= 0 {
+			_ = syscall.Close(fd)
+		}
+	}
+}
+
+func indexZero(name []byte) int {
+	for i, c := range name {
+		if c == 0 {
+			return i
+		}
+	}
+	return len(name)
+}
@@ -0,0 +1,101 @@
+//go:build windows
+
+package watch
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                        = syscall.NewLazyDLL("kernel32.dll")
+	procFindFirstChangeNotification = kernel32.NewProc("FindFirstChangeNotificationW")
+	procFindNextChangeNotification  = kernel32.NewProc("FindNextChangeNotification")
+	procFindCloseChangeNotification = kernel32.NewProc("FindCloseChangeNotification")
+	procWaitForMultipleObjects      = kernel32.NewProc("WaitForMultipleObjects")
+	procCreateEvent                 = kernel32.NewProc("CreateEventW")
+	procSetEvent                    = kernel32.NewProc("SetEvent")
+)
+
+const (
+	fileNotifyChangeFileName  = 0x00000001
+	fileNotifyChangeDirName   = 0x00000002
+	fileNotifyChangeSize      = 0x00000008
+	fileNotifyChangeLastWrite = 0x00000010
+	waitObject0               = 0x00000000
+	waitFailed                = 0xFFFFFFFF
+	infinite                  = 0xFFFFFFFF
+	invalidHandle             = ^uintptr(0)
+)
+
+// notification observes a directory through change notifications, blocking until either a notification or the close
+// event arrives.  As Windows reports only that something changed, the tree is rescanned and the differences reported.
+type notification struct {
+	dir       string
+	recursive bool
+	handle    uintptr
+	cancel    uintptr
+	state     snapshot
+}
+
+func newBackend(dir string, recursive bool) (backend, error) {
+	path, err := syscall.UTF16PtrFromString(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var subtree uintptr
+	if recursive {
+		subtree = 1
+	}
+	filter := uintptr(fileNotifyChangeFileName | fileNotifyChangeDirName | fileNotifyChangeSize | fileNotifyChangeLastWrite)
+	handle, _, err := procFindFirstChangeNotification.Call(uintptr(unsafe.Pointer(path)), subtree, filter)
+	if handle == invalidHandle {
+		return nil, err
+	}
+
+	cancel, _, err := procCreateEvent.Call(0, 1, 0, 0)
+	if cancel == 0 {
+		_, _, _ = procFindCloseChangeNotification.Call(handle)
+		return nil, err
+	}
+
+	return &notification{dir: dir, recursive: recursive, handle: handle, cancel: cancel, state: scan(dir, recursive)}, nil
+}
+
+func (b *notification) run(emit func(Event)) error {
+	defer func() {
+		_, _, _ = procFindCloseChangeNotification.Call(b.handle)
+	}()
+
+	handles := [2]uintptr{b.handle, b.cancel}
+	for {
+		result, _, err := procWaitForMultipleObjects.Call(2, uintptr(unsafe.Pointer(&handles[0])), 0, infinite)
+		switch result {
+		case waitObject0:
+		case waitObject0 + 1:
+			return nil
+		default:
+			return err
+		}
+
+		if _, err := os.Stat(b.dir); err != nil {
+			return err
+		}
+		state := scan(b.dir, b.recursive)
+		state.diff(b.state, emit)
+		b.state = state
+
+		if ok, _, err := procFindNextChangeNotification.Call(b.handle); ok == 0 {
+			return err
+		}
+	}
+}
+
+func (b *notification) close() error {
+	if ok, _, err := procSetEvent.Call(b.cancel); ok == 0 {
+		return err
+	}
+	return nil
+}
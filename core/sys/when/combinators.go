@@ -0,0 +1,184 @@
+package when
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/edge"
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// And provides a potential that activates when every provided potential is high.
+//
+// NOTE: Just like Go's && operator, this short-circuits - once a potential is low, the remaining potentials aren't
+// consulted, meaning any stateful potentials after it won't observe that impulse.
+func And(potentials ...func(*std.Impulse) bool) func(*std.Impulse) bool {
+	return func(imp *std.Impulse) bool {
+		for _, potential := range potentials {
+			if !potential(imp) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or provides a potential that activates when any provided potential is high.
+//
+// NOTE: Just like Go's || operator, this short-circuits - once a potential is high, the remaining potentials aren't
+// consulted, meaning any stateful potentials after it won't observe that impulse.
+func Or(potentials ...func(*std.Impulse) bool) func(*std.Impulse) bool {
+	return func(imp *std.Impulse) bool {
+		for _, potential := range potentials {
+			if potential(imp) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not provides a potential that activates when the provided potential is low.
+func Not(potential func(*std.Impulse) bool) func(*std.Impulse) bool {
+	return func(imp *std.Impulse) bool {
+		return !potential(imp)
+	}
+}
+
+// Xor provides a potential that activates when an odd number of the provided potentials are high - for two
+// potentials, that's when exactly one of them is.  Every potential is consulted on every impulse.
+func Xor(potentials ...func(*std.Impulse) bool) func(*std.Impulse) bool {
+	return func(imp *std.Impulse) bool {
+		high := false
+		for _, potential := range potentials {
+			if potential(imp) {
+				high = !high
+			}
+		}
+		return high
+	}
+}
+
+// Debounce provides a potential that activates once the provided potential has held high for the entire duration,
+// ignoring any flickers shorter than it.  It activates only once per high period - the potential must go low again
+// before it can next activate.
+func Debounce(duration time.Duration, potential func(*std.Impulse) bool) func(*std.Impulse) bool {
+	var since time.Time
+	var fired bool
+	return func(imp *std.Impulse) bool {
+		if !potential(imp) {
+			since = time.Time{}
+			fired = false
+			return false
+		}
+
+		now := imp.Clock().Now()
+		if since.IsZero() {
+			since = now
+		}
+		if !fired && now.Sub(since) >= duration {
+			fired = true
+			return true
+		}
+		return false
+	}
+}
+
+// Throttle provides a potential that follows the provided potential, but activates at most once per duration.
+func Throttle(duration time.Duration, potential func(*std.Impulse) bool) func(*std.Impulse) bool {
+	var last time.Time
+	return func(imp *std.Impulse) bool {
+		if !potential(imp) {
+			return false
+		}
+
+		now := imp.Clock().Now()
+		if !last.IsZero() && now.Sub(last) < duration {
+			return false
+		}
+		last = now
+		return true
+	}
+}
+
+// AfterN provides a potential that follows the provided potential, but only once it has been high n times - the
+// first n-1 activations are swallowed.
+func AfterN(n uint, potential func(*std.Impulse) bool) func(*std.Impulse) bool {
+	var count uint
+	return func(imp *std.Impulse) bool {
+		if !potential(imp) {
+			return false
+		}
+		if count < n {
+			count++
+		}
+		return count >= n
+	}
+}
+
+// Every provides a potential that activates on every nth beat of the cortex, as counted by the Impulse's Beat.
+//
+// NOTE: If the cortex has a positive BeatPeriod, its beats loop back to zero after it - so Every realigns to the start
+// of each period.  If you need a potential which is exactly aligned across periods, choose an n which divides evenly
+// into BeatPeriod+1 - or see Cortex.CreateSubCortex.
+func Every(n uint) func(*std.Impulse) bool {
+	if n == 0 {
+		panic("every must be provided a positive number of beats")
+	}
+	return func(imp *std.Impulse) bool {
+		return imp.Beat%n == 0
+	}
+}
+
+// Between provides a potential that activates while the impulse's clock lies within [start, end).
+func Between(start time.Time, end time.Time) func(*std.Impulse) bool {
+	return func(imp *std.Impulse) bool {
+		now := imp.Clock().Now()
+		return !now.Before(start) && now.Before(end)
+	}
+}
+
+// Jitter provides a potential that delays each activation of the provided potential by a random duration within
+// [0, maximum) - useful for spreading out the activations of many neurons which would otherwise fire in lockstep.
+//
+// NOTE: The provided potential isn't consulted while an activation is pending, so activations never queue up.
+func Jitter(maximum time.Duration, potential func(*std.Impulse) bool) func(*std.Impulse) bool {
+	var due time.Time
+	return func(imp *std.Impulse) bool {
+		now := imp.Clock().Now()
+		if due.IsZero() {
+			if !potential(imp) {
+				return false
+			}
+			due = now
+			if maximum > 0 {
+				due = now.Add(rand.N(maximum))
+			}
+		}
+		if now.Before(due) {
+			return false
+		}
+		due = time.Time{}
+		return true
+	}
+}
+
+// Edge provides a potential that activates only when the provided potential transitions in the direction of the
+// provided kind - rather than for as long as it remains high.  The potential is considered low before its first impulse.
+func Edge(kind edge.Kind, potential func(*std.Impulse) bool) func(*std.Impulse) bool {
+	var last bool
+	return func(imp *std.Impulse) bool {
+		current := potential(imp)
+		previous := last
+		last = current
+
+		switch kind {
+		case edge.Rising:
+			return current && !previous
+		case edge.Falling:
+			return !current && previous
+		default:
+			return current != previous
+		}
+	}
+}
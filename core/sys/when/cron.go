@@ -0,0 +1,280 @@
+package when
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/misfire"
+	"git.ignitelabs.net/janos/core/std"
+)
+
+// Cron provides a potential that activates at the wall-clock moments described by a cron expression, observed within
+// the provided time zone - or UTC, if nil.  For example, every weekday at 02:00 UTC:
+//
+//	when.Cron("0 2 * * MON-FRI", time.UTC)
+//
+// The schedule is measured from the first impulse the potential observes.  Should the potential miss firings - for
+// instance, if its cortex was muted through them - the provided misfire policy decides whether they're skipped or caught
+// up on.  If no policy is provided, misfire.Skip is implied.
+//
+// NOTE: This panics if the expression is invalid - see ParseCron for the supported syntax and how daylight saving
+// time transitions are handled.  When paired with life.Looping, the potential is consulted on every beat - so the
+// cortex's frequency defines how precisely it lands on each scheduled moment.
+func Cron(expr string, tz *time.Location, policy ...misfire.Policy) func(*std.Impulse) bool {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		panic(err)
+	}
+	if tz == nil {
+		tz = time.UTC
+	}
+	p := misfire.Skip
+	if len(policy) > 0 {
+		p = policy[0]
+	}
+
+	var next time.Time
+	return func(imp *std.Impulse) bool {
+		now := imp.Clock().Now().In(tz)
+		if next.IsZero() {
+			next = schedule.Next(now)
+		}
+		if next.IsZero() || now.Before(next) {
+			return false
+		}
+
+		if p == misfire.CatchUp {
+			next = schedule.Next(next)
+		} else {
+			next = schedule.Next(now)
+		}
+		return true
+	}
+}
+
+// A Schedule is a parsed cron expression.  See ParseCron
+type Schedule struct {
+	second, minute, hour, dom, month, dow uint64
+	anyDay                                bool // NOTE: anyDay is set when either day field is '*', so both must match
+	every                                 time.Duration
+}
+
+// cronMacros maps each supported macro to its equivalent expression.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var cronMonths = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+var cronDays = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// ParseCron parses a standard cron expression of either five fields - minute, hour, day of month, month and day of
+// week - or six, with a leading seconds field.  Each field accepts '*' (or '?'), single values, ranges ("1-5"), lists
+// ("1,15") and steps ("*/15", "10-40/10"), and the month and day of week fields accept three-letter names ("JAN",
+// "MON-FRI").  Sunday is either 0 or 7.  As is traditional, when both the day of month and day of week are restricted,
+// a day matching either fires.
+//
+// The macros @yearly (or @annually), @monthly, @weekly, @daily (or @midnight) and @hourly are supported, as is
+// "@every <duration>" for a fixed interval parsed by time.ParseDuration.
+//
+// Schedules are evaluated in wall-clock time, so daylight saving transitions are handled as people expect - a moment
+// which is skipped as the clocks spring forward fires once, shifted past the transition by the size of the gap, and a
+// moment which occurs twice as the clocks fall back fires only once.
+func ParseCron(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %w", expr, err)
+		}
+		if every <= 0 {
+			return nil, fmt.Errorf("invalid cron expression '%s': the interval must be positive", expr)
+		}
+		return &Schedule{every: every}, nil
+	}
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{}
+	var err error
+	parse := func(field string, minimum, maximum int, names []string) uint64 {
+		if err != nil {
+			return 0
+		}
+		var mask uint64
+		mask, err = parseCronField(field, minimum, maximum, names)
+		if err != nil {
+			err = fmt.Errorf("invalid cron expression '%s': %w", expr, err)
+		}
+		return mask
+	}
+	s.second = parse(fields[0], 0, 59, nil)
+	s.minute = parse(fields[1], 0, 59, nil)
+	s.hour = parse(fields[2], 0, 23, nil)
+	s.dom = parse(fields[3], 1, 31, nil)
+	s.month = parse(fields[4], 1, 12, cronMonths)
+	s.dow = parse(fields[5], 0, 7, cronDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sunday can be written as either 0 or 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.anyDay = isCronWildcard(fields[3]) || isCronWildcard(fields[5])
+	return s, nil
+}
+
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseCronField parses a single cron field into a bitmask of the values it matches.
+func parseCronField(field string, minimum, maximum int, names []string) (uint64, error) {
+	value := func(s string) (int, error) {
+		for i, name := range names {
+			if name != "" && strings.EqualFold(s, name) {
+				return i, nil
+			}
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil || v < minimum || v > maximum {
+			return 0, fmt.Errorf("'%s' must be within [%d, %d]", s, minimum, maximum)
+		}
+		return v, nil
+	}
+
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		span, stepping, stepped := strings.Cut(part, "/")
+		step := 1
+		if stepped {
+			var err error
+			step, err = strconv.Atoi(stepping)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", stepping)
+			}
+		}
+
+		low, high := minimum, maximum
+		if !isCronWildcard(span) {
+			from, to, ranged := strings.Cut(span, "-")
+			var err error
+			if low, err = value(from); err != nil {
+				return 0, err
+			}
+			high = low
+			if ranged {
+				if high, err = value(to); err != nil {
+					return 0, err
+				}
+			} else if stepped {
+				high = maximum
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range '%s'", span)
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			mask |= 1 << v
+		}
+	}
+	return mask, nil
+}
+
+// cronHorizon bounds how far ahead Next searches for a matching day - far enough for any schedule with a match, such as the 29th of February.
+const cronHorizon = 8 * 366
+
+// Next returns the first moment strictly after the provided moment which matches the schedule, in the provided moment's
+// location - or the zero time, if the schedule can never match (for instance, the 30th of February).
+func (s *Schedule) Next(after time.Time) time.Time {
+	if s.every > 0 {
+		return after.Add(s.every)
+	}
+
+	loc := after.Location()
+	y, m, d := after.Date()
+	for day := 0; day < cronHorizon; day++ {
+		date := time.Date(y, m, d+day, 12, 0, 0, 0, loc)
+		if !s.matchesDay(date) {
+			continue
+		}
+		if next := s.nextWithin(date, after); !next.IsZero() {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(date time.Time) bool {
+	if s.month&(1<<uint(date.Month())) == 0 {
+		return false
+	}
+	dom := s.dom&(1<<uint(date.Day())) != 0
+	dow := s.dow&(1<<uint(date.Weekday())) != 0
+	if s.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// nextWithin returns the first moment on the provided day, strictly after the provided moment, which matches the
+// schedule's time of day - or the zero time, if there isn't one.
+func (s *Schedule) nextWithin(date time.Time, after time.Time) time.Time {
+	y, m, d := date.Date()
+	loc := date.Location()
+
+	for hours := s.hour; hours != 0; hours &= hours - 1 {
+		h := bits.TrailingZeros64(hours)
+		if !wallClock(y, m, d, h, 59, 59, loc).After(after) {
+			continue
+		}
+		for minutes := s.minute; minutes != 0; minutes &= minutes - 1 {
+			mi := bits.TrailingZeros64(minutes)
+			if !wallClock(y, m, d, h, mi, 59, loc).After(after) {
+				continue
+			}
+			for seconds := s.second; seconds != 0; seconds &= seconds - 1 {
+				if moment := wallClock(y, m, d, h, mi, bits.TrailingZeros64(seconds), loc); moment.After(after) {
+					return moment
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// wallClock returns the instant the provided wall-clock moment occurs within the location.  A moment which occurs
+// twice as the clocks fall back resolves to a single instant, and a moment which is skipped as the clocks spring
+// forward is shifted past the transition by the size of the gap - so 02:30 becomes 03:30 when 02:00 jumps to 03:00.
+func wallClock(y int, m time.Month, d, h, mi, s int, loc *time.Location) time.Time {
+	moment := time.Date(y, m, d, h, mi, s, 0, loc)
+
+	requested := time.Date(y, m, d, h, mi, s, 0, time.UTC)
+	resolved := time.Date(moment.Year(), moment.Month(), moment.Day(), moment.Hour(), moment.Minute(), moment.Second(), 0, time.UTC)
+	if resolved.Before(requested) {
+		// NOTE: time.Date may resolve a skipped moment to before the transition - it must always land after it
+		moment = moment.Add(requested.Sub(resolved))
+	}
+	return moment
+}
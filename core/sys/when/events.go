@@ -0,0 +1,223 @@
+package when
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+
+	"git.ignitelabs.net/janos/core/enum/overflow"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/atlas"
+	"git.ignitelabs.net/janos/core/sys/rec"
+	"git.ignitelabs.net/janos/core/sys/watch"
+)
+
+// NOTE: The potentials below are driven by events, rather than by polling.  The first time one is consulted, it begins
+// listening to its source for as long as the cortex lives - and each event wakes the cortex through Cortex.Impulse, so
+// the neuron reacts immediately rather than on the next timed beat.  This means the cortex can beat slowly, or even
+// remain muted, while still reacting to events as they happen.  A cortex muted before its first beat hasn't yet wired
+// in its synapses to consult them, so their potentials should be wrapped in Listening.
+
+// Listening consults the provided potential on behalf of the cortex straight away, rather than waiting for the synapse
+// to be wired in on the cortex's next beat - starting an event-driven potential's listener immediately, so a cortex
+// which is muted before its first beat still reacts to events.  Should that first consultation activate the
+// potential, the activation is held for the neuron's first consultation.
+//
+//	cortex.Spark(std.NewSynapse(life.Looping, "reload", reload, when.Listening(cortex, when.Signaled(syscall.SIGHUP))))
+//	cortex.Mute()
+func Listening(cortex *std.Cortex, potential func(*std.Impulse) bool) func(*std.Impulse) bool {
+	var held atomic.Bool
+	held.Store(potential(&std.Impulse{Cortex: cortex}))
+
+	return func(imp *std.Impulse) bool {
+		if held.Swap(false) {
+			return true
+		}
+		return potential(imp)
+	}
+}
+
+// Received provides a potential that activates once for every value received from the provided channel.  If a
+// destination is provided, each value is written into it just before the potential activates.
+//
+// NOTE: Up to atlas.SynapticChannelLimit values queue while the neuron catches up, beyond which the potential stops
+// receiving from the channel until there's room again - see ReceivedWith
+func Received[T any](channel <-chan T, into ...*T) func(*std.Impulse) bool {
+	return ReceivedWith(channel, int(atlas.SynapticChannelLimit), overflow.Block, into...)
+}
+
+// ReceivedWith provides a potential that activates once for every value received from the provided channel, queueing
+// up to capacity values while the neuron catches up - beyond which the overflow policy applies.  If a destination is
+// provided, each value is written into it just before the potential activates.
+//
+// NOTE: Blocking stops receiving from the channel until there's room again, while overflow.Coalesce behaves like
+// overflow.DropNewest - as distinct values can't be merged.
+func ReceivedWith[T any](channel <-chan T, capacity int, policy overflow.Policy, into ...*T) func(*std.Impulse) bool {
+	if capacity <= 0 {
+		panic("a received potential's capacity must be positive")
+	}
+
+	var destination *T
+	if len(into) > 0 {
+		destination = into[0]
+	}
+
+	return evented(capacity, policy, destination, func(ctx context.Context, emit func(T)) {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case value, ok := <-channel:
+					if !ok {
+						return
+					}
+					emit(value)
+				}
+			}
+		}()
+	})
+}
+
+// Modified provides a potential that activates whenever the file at the provided path is created, modified or removed.
+// Changes which happen faster than the neuron can react coalesce into a single activation.
+//
+// NOTE: The file's directory is observed in its place, allowing the file to be replaced or created later - see watch.New
+func Modified(path string) func(*std.Impulse) bool {
+	return evented(1, overflow.DropOldest, nil, func(ctx context.Context, emit func(struct{})) {
+		w, err := watch.New(path)
+		if err != nil {
+			rec.Errorf(path, "failed to watch: %v\n", err)
+			return
+		}
+		context.AfterFunc(ctx, func() {
+			_ = w.Close()
+		})
+
+		go func() {
+			for range w.Events() {
+				emit(struct{}{})
+			}
+		}()
+	})
+}
+
+// Signaled provides a potential that activates whenever the process receives any of the provided operating system
+// signals.  Signals which arrive faster than the neuron can react coalesce into a single activation.
+func Signaled(signals ...os.Signal) func(*std.Impulse) bool {
+	return evented(1, overflow.DropOldest, nil, func(ctx context.Context, emit func(os.Signal)) {
+		received := make(chan os.Signal, 1)
+		signal.Notify(received, signals...)
+
+		go func() {
+			defer signal.Stop(received)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case sig := <-received:
+					emit(sig)
+				}
+			}
+		}()
+	})
+}
+
+// Changed provides a potential that activates whenever the provided thought changes.  Changes which happen faster than
+// the neuron can react coalesce into a single activation - see std.Thought.Watch
+func Changed[T any](thought *std.Thought[T]) func(*std.Impulse) bool {
+	return evented(1, overflow.DropOldest, nil, func(ctx context.Context, emit func(uint64)) {
+		versions := thought.Watch(ctx)
+		go func() {
+			for version := range versions {
+				emit(version)
+			}
+		}()
+	})
+}
+
+// evented provides a potential which activates for each event emitted by the provided listener, waking the cortex
+// through an impulse as each one arrives.  The listener is started the first time the potential is consulted, and must
+// begin listening before it returns - emitting from its own goroutine until the cortex's context is done.  Up to
+// capacity events queue and activate the potential once each, beyond which the overflow policy applies - a capacity of
+// one with overflow.DropOldest coalesces events which haven't yet activated the potential into the latest.
+//
+// NOTE: The listener is bound to the cortex, rather than the impulse, so it outlives a neuron restarted by a Supervisor.
+func evented[T any](capacity int, policy overflow.Policy, into *T, listen func(ctx context.Context, emit func(T))) func(*std.Impulse) bool {
+	pending := make(chan T, capacity)
+	var gate sync.Mutex
+	var listening sync.Once
+
+	return func(imp *std.Impulse) bool {
+		listening.Do(func() {
+			cortex := imp.Cortex
+			ctx := imp.Context()
+			if cortex != nil {
+				ctx = cortex.Context()
+			}
+			listen(ctx, func(event T) {
+				if !enqueue(ctx, &gate, pending, event, policy) {
+					return
+				}
+				if cortex != nil {
+					cortex.Impulse()
+				}
+			})
+		})
+
+		var event T
+		select {
+		case event = <-pending:
+		default:
+			return false
+		}
+		remaining := len(pending)
+
+		if into != nil {
+			*into = event
+		}
+		if remaining > 0 && imp.Cortex != nil {
+			// NOTE: Each impulse activates the potential once, so queued events keep waking the cortex until drained
+			go imp.Cortex.Impulse()
+		}
+		return true
+	}
+}
+
+// enqueue places the event in the pending queue according to the overflow policy, returning whether it was queued.
+func enqueue[T any](ctx context.Context, gate *sync.Mutex, pending chan T, event T, policy overflow.Policy) bool {
+	switch policy {
+	case overflow.DropNewest, overflow.Coalesce:
+		select {
+		case pending <- event:
+			return true
+		default:
+			return false
+		}
+	case overflow.DropOldest:
+		gate.Lock()
+		defer gate.Unlock()
+
+		for {
+			select {
+			case pending <- event:
+				return true
+			default:
+			}
+
+			select {
+			case <-pending:
+			default:
+			}
+		}
+	default:
+		select {
+		case pending <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
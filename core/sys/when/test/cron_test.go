@@ -0,0 +1,96 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/misfire"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/when"
+)
+
+func next(t *testing.T, expr string, after time.Time, want ...time.Time) {
+	t.Helper()
+	schedule, err := when.ParseCron(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range want {
+		after = schedule.Next(after)
+		if !after.Equal(w) {
+			t.Errorf("%s: expected %v, got %v", expr, w, after)
+			return
+		}
+	}
+}
+
+func Test_Cron_Next(t *testing.T) {
+	// Friday, the 1st of March 2024
+	start := time.Date(2024, 3, 1, 1, 30, 0, 0, time.UTC)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	next(t, "0 2 * * MON-FRI", start, at(1, 2, 0), at(4, 2, 0), at(5, 2, 0))
+	next(t, "*/20 9-10 * * *", start, at(1, 9, 0), at(1, 9, 20), at(1, 9, 40), at(1, 10, 0))
+	next(t, "@daily", start, at(2, 0, 0), at(3, 0, 0))
+	next(t, "30 0 0 1 * *", start, time.Date(2024, 4, 1, 0, 0, 30, 0, time.UTC))
+	next(t, "0 0 15 * SUN", start, at(3, 0, 0), at(10, 0, 0), at(15, 0, 0), at(17, 0, 0))
+	next(t, "0 0 29 FEB *", start, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC))
+	next(t, "@every 90m", start, at(1, 3, 0), at(1, 4, 30))
+
+	for _, invalid := range []string{"* * *", "60 * * * *", "* * * JAN-FOO *", "5-1 * * * *", "*/0 * * * *", "@every soon"} {
+		if _, err := when.ParseCron(invalid); err == nil {
+			t.Errorf("expected '%s' to be invalid", invalid)
+		}
+	}
+}
+
+func Test_Cron_DaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data is unavailable")
+	}
+
+	// The clocks spring forward from 02:00 to 03:00 on the 10th of March 2024 - the skipped 02:30 fires once, just after
+	spring := time.Date(2024, 3, 10, 0, 0, 0, 0, newYork)
+	next(t, "30 2 * * *", spring, time.Date(2024, 3, 10, 3, 30, 0, 0, newYork), time.Date(2024, 3, 11, 2, 30, 0, 0, newYork))
+
+	// The clocks fall back from 02:00 to 01:00 on the 3rd of November 2024 - the repeated 01:30 fires only once
+	fall := time.Date(2024, 11, 3, 0, 0, 0, 0, newYork)
+	schedule, _ := when.ParseCron("30 1 * * *")
+	first := schedule.Next(fall)
+	if second := schedule.Next(first); second.Sub(first) < 24*time.Hour {
+		t.Errorf("expected the repeated moment to fire once, got %v and then %v", first, second)
+	}
+}
+
+func Test_Cron_Misfire(t *testing.T) {
+	for _, policy := range []misfire.Policy{misfire.Skip, misfire.CatchUp} {
+		clock := std.NewVirtualClock(time.Date(2024, 3, 1, 0, 0, 30, 0, time.UTC))
+		imp := &std.Impulse{Cortex: std.NewCortexWithClock("cron", clock)}
+		potential := when.Cron("* * * * *", nil, policy)
+
+		if potential(imp) {
+			t.Fatalf("%v: expected no firing before the first scheduled minute", policy)
+		}
+
+		// Miss three firings, then observe every second
+		clock.Advance(3 * time.Minute)
+		var fired int
+		for i := 0; i < 5; i++ {
+			if potential(imp) {
+				fired++
+			}
+			clock.Advance(time.Second)
+		}
+
+		want := 1
+		if policy == misfire.CatchUp {
+			want = 3
+		}
+		if fired != want {
+			t.Errorf("%v: expected %d firings, got %d", policy, want, fired)
+		}
+	}
+}
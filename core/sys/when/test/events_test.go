@@ -0,0 +1,171 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/life"
+	"git.ignitelabs.net/janos/core/enum/overflow"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/when"
+)
+
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition was never met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// react sparks a muted cortex, whose clock never advances, with a looping neuron driven by the provided potential.
+func react(t *testing.T, potential func(*std.Impulse) bool, action ...func()) (*std.Cortex, func() int) {
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	cortex := std.NewCortexWithClock("events", clock)
+	cortex.Frequency = 1
+
+	var gate sync.Mutex
+	var count int
+	err := cortex.Spark(std.NewSynapse(life.Looping, "reactor", func(*std.Impulse) {
+		gate.Lock()
+		defer gate.Unlock()
+		count++
+		for _, a := range action {
+			a()
+		}
+	}, potential))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first beat wires in the neuron, which then begins listening
+	clock.Step(cortex)
+	cortex.Mute()
+
+	return cortex, func() int {
+		gate.Lock()
+		defer gate.Unlock()
+		return count
+	}
+}
+
+func Test_When_Received(t *testing.T) {
+	events := make(chan int)
+	var value int
+	var values []int
+	cortex, count := react(t, when.Received(events, &value), func() { values = append(values, value) })
+	defer cortex.Shutdown()
+
+	for i := 1; i <= 3; i++ {
+		events <- i
+	}
+	eventually(t, func() bool { return count() == 3 })
+	if len(values) != 3 || values[0] != 1 || values[2] != 3 {
+		t.Errorf("expected every value to be received in order, got %v", values)
+	}
+}
+
+func Test_When_Changed(t *testing.T) {
+	thought := std.NewThought("idle")
+	cortex, count := react(t, when.Changed(thought))
+	defer cortex.Shutdown()
+
+	thought.Store("thinking")
+	eventually(t, func() bool { return count() == 1 })
+	thought.Store("revealed")
+	eventually(t, func() bool { return count() == 2 })
+}
+
+func Test_When_Modified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watched")
+	cortex, count := react(t, when.Modified(path))
+	defer cortex.Shutdown()
+
+	if err := os.WriteFile(path, []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return count() >= 1 })
+}
+
+func Test_When_MutedBeforeFirstBeat(t *testing.T) {
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	cortex := std.NewCortexWithClock("muted", clock)
+	cortex.Frequency = 1
+	defer cortex.Shutdown()
+
+	events := make(chan int, 2)
+	var gate sync.Mutex
+	var values []int
+	var value int
+	err := cortex.Spark(std.NewSynapse(life.Looping, "reactor", func(*std.Impulse) {
+		gate.Lock()
+		defer gate.Unlock()
+		values = append(values, value)
+	}, when.Listening(cortex, when.Received(events, &value))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: The clock never advances, so the synapse is only ever wired in by the events themselves
+	cortex.Mute()
+	for i := 1; i <= 2; i++ {
+		events <- i
+	}
+	eventually(t, func() bool {
+		gate.Lock()
+		defer gate.Unlock()
+		return len(values) == 2
+	})
+	if values[0] != 1 || values[1] != 2 {
+		t.Errorf("expected every value to be received in order, got %v", values)
+	}
+	if cortex.Beat() > 2 {
+		t.Errorf("expected the cortex to remain muted between events, got beat %d", cortex.Beat())
+	}
+}
+
+func Test_When_ReceivedWith(t *testing.T) {
+	events := make(chan int)
+	var value int
+	potential := when.ReceivedWith(events, 2, overflow.Block, &value)
+	imp := &std.Impulse{}
+	if potential(imp) {
+		t.Fatal("expected no activation before any value is received")
+	}
+
+	// NOTE: Two values fill the queue, and the third is held by the receiver until there's room
+	for i := 1; i <= 3; i++ {
+		events <- i
+	}
+	select {
+	case events <- 4:
+		t.Fatal("expected a full queue to stop receiving from the channel")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if !potential(imp) || value != 1 {
+		t.Fatalf("expected the first value to activate the potential, got %d", value)
+	}
+	select {
+	case events <- 4:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the queue to resume receiving once there was room")
+	}
+
+	var values []int
+	eventually(t, func() bool {
+		for potential(imp) {
+			values = append(values, value)
+		}
+		return len(values) == 3
+	})
+	if values[0] != 2 || values[1] != 3 || values[2] != 4 {
+		t.Errorf("expected the remaining values in order, got %v", values)
+	}
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"git.ignitelabs.net/janos/core/enum/edge"
+	"git.ignitelabs.net/janos/core/std"
+	"git.ignitelabs.net/janos/core/sys/when"
+)
+
+// signal provides a potential which follows the provided pattern, one value per impulse.
+func signal(pattern ...bool) func(*std.Impulse) bool {
+	var i int
+	return func(*std.Impulse) bool {
+		v := pattern[i%len(pattern)]
+		i++
+		return v
+	}
+}
+
+// observe evaluates the potential once per step of the clock, returning each result.
+func observe(potential func(*std.Impulse) bool, steps int, step time.Duration) []bool {
+	clock := std.NewVirtualClock(time.Unix(1000, 0))
+	imp := &std.Impulse{Cortex: std.NewCortexWithClock("observer", clock)}
+
+	out := make([]bool, steps)
+	for i := range out {
+		out[i] = potential(imp)
+		clock.Advance(step)
+	}
+	return out
+}
+
+func expect(t *testing.T, what string, got []bool, want ...bool) {
+	t.Helper()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s: expected %v, got %v", what, want, got)
+			return
+		}
+	}
+}
+
+func Test_When_Logic(t *testing.T) {
+	on, off := when.Always(), when.Not(when.Always())
+
+	got := observe(when.And(on, signal(true, false)), 4, time.Second)
+	expect(t, "and", got, true, false, true, false)
+	got = observe(when.Or(off, signal(false, true)), 4, time.Second)
+	expect(t, "or", got, false, true, false, true)
+	got = observe(when.Xor(on, signal(true, false)), 4, time.Second)
+	expect(t, "xor", got, false, true, false, true)
+}
+
+func Test_When_Timing(t *testing.T) {
+	got := observe(when.Debounce(2*time.Second, signal(true, false, true, true, true, true)), 6, time.Second)
+	expect(t, "debounce", got, false, false, false, false, true, false)
+
+	got = observe(when.Throttle(3*time.Second, when.Always()), 7, time.Second)
+	expect(t, "throttle", got, true, false, false, true, false, false, true)
+
+	got = observe(when.AfterN(3, signal(true, false)), 8, time.Second)
+	expect(t, "after n", got, false, false, false, false, true, false, true, false)
+
+	start := time.Unix(1002, 0)
+	got = observe(when.Between(start, start.Add(2*time.Second)), 5, time.Second)
+	expect(t, "between", got, false, false, true, true, false)
+
+	got = observe(when.Jitter(5*time.Second, when.Always()), 12, time.Second)
+	var fired int
+	for _, v := range got {
+		if v {
+			fired++
+		}
+	}
+	if fired < 2 {
+		t.Errorf("jitter: expected the activations to be delayed by at most 5 seconds each, got %v", got)
+	}
+}
+
+func Test_When_Periodic(t *testing.T) {
+	// NOTE: The first evaluation anchors the period, and a full period elapsing is enough to activate
+	got := observe(when.Periodically(2*time.Second), 7, time.Second)
+	expect(t, "periodically", got, false, false, true, false, true, false, true)
+
+	got = observe(when.Frequency(0.5), 7, time.Second)
+	expect(t, "frequency", got, false, false, true, false, true, false, true)
+
+	got = observe(when.Resonant(1.0, 3.0), 7, time.Second)
+	expect(t, "resonant", got, false, false, false, true, false, false, true)
+}
+
+func Test_When_Edge(t *testing.T) {
+	pattern := []bool{false, true, true, false, true}
+
+	got := observe(when.Edge(edge.Rising, signal(pattern...)), 5, time.Second)
+	expect(t, "rising", got, false, true, false, false, true)
+	got = observe(when.Edge(edge.Falling, signal(pattern...)), 5, time.Second)
+	expect(t, "falling", got, false, false, false, true, false)
+	got = observe(when.Edge(edge.Both, signal(pattern...)), 5, time.Second)
+	expect(t, "both", got, false, true, false, true, true)
+}
+
+func Test_When_Every(t *testing.T) {
+	every := when.Every(3)
+	imp := &std.Impulse{}
+	var got []bool
+	for beat := uint(0); beat < 7; beat++ {
+		imp.Beat = beat
+		got = append(got, every(imp))
+	}
+	expect(t, "every", got, true, false, false, true, false, false, true)
+}
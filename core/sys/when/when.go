@@ -11,14 +11,26 @@ func Always() func(*std.Impulse) bool {
 	return func(*std.Impulse) bool { return true }
 }
 
+// Periodically provides a potential that activates once the provided duration has elapsed since its last activation.
+//
+// NOTE: The period is measured against the impulse's clock, so it's anchored on the first evaluation rather than at
+// construction - and it activates once a full period has elapsed, inclusively, so that a virtual clock stepping in exact
+// multiples of the period doesn't slip a beat.  See PeriodicallyRef
 func Periodically(duration time.Duration) func(*std.Impulse) bool {
 	return PeriodicallyRef(&duration)
 }
+
+// PeriodicallyRef provides a potential that activates once the referenced duration has elapsed since its last activation.
+//
+// NOTE: This is anchored on its first evaluation and activates inclusively of the period - see Periodically.
 func PeriodicallyRef(duration *time.Duration) func(*std.Impulse) bool {
-	last := time.Now()
-	return func(*std.Impulse) bool {
-		now := time.Now()
-		if now.Sub(last) > *duration {
+	var last time.Time
+	return func(imp *std.Impulse) bool {
+		now := imp.Clock().Now()
+		if last.IsZero() {
+			last = now
+		}
+		if now.Sub(last) >= *duration {
 			last = now
 			return true
 		}
@@ -26,17 +38,27 @@ func PeriodicallyRef(duration *time.Duration) func(*std.Impulse) bool {
 	}
 }
 
+// FrequencyRef provides a potential that activates at the referenced frequency (in Hertz).
+//
+// NOTE: This is anchored on its first evaluation and activates inclusively of the period - see Periodically.
 func FrequencyRef[T num.Primitive](hertz *T) func(*std.Impulse) bool {
-	last := time.Now()
-	return func(*std.Impulse) bool {
-		now := time.Now()
-		if now.Sub(last) > HertzToDuration(*hertz) {
+	var last time.Time
+	return func(imp *std.Impulse) bool {
+		now := imp.Clock().Now()
+		if last.IsZero() {
+			last = now
+		}
+		if now.Sub(last) >= HertzToDuration(*hertz) {
 			last = now
 			return true
 		}
 		return false
 	}
 }
+
+// Frequency provides a potential that activates at the provided frequency (in Hertz).
+//
+// NOTE: This is anchored on its first evaluation and activates inclusively of the period - see Periodically.
 func Frequency[T num.Primitive](hertz T) func(*std.Impulse) bool {
 	return FrequencyRef(&hertz)
 }
@@ -44,6 +66,9 @@ func Frequency[T num.Primitive](hertz T) func(*std.Impulse) bool {
 // Resonant provides a potential that activates at a sympathetic frequency (in Hertz) to the source frequency.
 //
 //	Resonance = Source / Subdivision
+//
+// NOTE: This observes the clock independently of the cortex's beats - if you need activations which stay exactly
+// aligned with every Nth beat, see Cortex.CreateSubCortex
 func Resonant[T num.Primitive](source T, subdivision T) func(*std.Impulse) bool {
 	return ResonantRef(&source, &subdivision)
 }
@@ -51,12 +76,17 @@ func Resonant[T num.Primitive](source T, subdivision T) func(*std.Impulse) bool
 // ResonantRef provides a potential that activates at a sympathetic frequency (in Hertz) to the source frequency.
 //
 //	Resonance = Source / Subdivision
+//
+// NOTE: This is anchored on its first evaluation and activates inclusively of the period - see Periodically.
 func ResonantRef[T num.Primitive](source *T, subdivision *T) func(*std.Impulse) bool {
-	last := time.Now()
-	return func(*std.Impulse) bool {
-		now := time.Now()
+	var last time.Time
+	return func(imp *std.Impulse) bool {
+		now := imp.Clock().Now()
+		if last.IsZero() {
+			last = now
+		}
 		resonance := *source / *subdivision
-		if now.Sub(last) > HertzToDuration(resonance) {
+		if now.Sub(last) >= HertzToDuration(resonance) {
 			last = now
 			return true
 		}
@@ -24,7 +24,12 @@ func main() {
 		cortex.Frequency = 1 //hz
 		cortex.Mute()
 
-		cortex.Synapses() <- neural.Net.Server(life.Looping, "enigmaneering.net", ":4242", Handler, func(imp *std.Impulse) {
+		router := neural.NewRouter().
+			Handle("{subdomain}.{domain...}", "/{path...}", Handler).
+			Handle("*", "/{path...}", Handler)
+		handler := neural.HTTP.Chain(router.Handler, neural.HTTP.RequestID(), neural.HTTP.Logging(), neural.HTTP.Recovery())
+
+		cortex.Synapses() <- neural.Net.Server(life.Looping, "enigmaneering.net", ":4242", handler, func(imp *std.Impulse) {
 			cortex.Impulse()
 		})
 
@@ -34,13 +39,11 @@ func main() {
 	}
 }
 
-var subdomainRegex = regexp.MustCompile(`[A-Za-z0-9](?:[A-Za-z0-9\-]{0,61}[A-Za-z0-9])?`)
 var enigmaRegex = regexp.MustCompile(`(?i)\be(\d+)(?:s(\d+))?\b`)
 
 func Handler(imp *std.Impulse) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		subdomain := subdomainRegex.FindString(r.Host)
-		parts := enigmaRegex.FindStringSubmatch(subdomain)
+		parts := enigmaRegex.FindStringSubmatch(r.PathValue("subdomain"))
 
 		if len(parts) > 1 {
 			redirect := "https://github.com/ignite-laboratories/enigmaneering" + "/tree/main/enigma" + parts[1]
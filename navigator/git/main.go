@@ -20,7 +20,10 @@ func main() {
 		cortex.Frequency = 1 //hz
 		cortex.Mute()
 
-		cortex.Synapses() <- neural.Net.Vanity(life.Looping, "git.ignitelabs.net", "https://github.com/ignite-laboratories", 4242, func(imp *std.Impulse) {
+		// NOTE: Any vanity mappings in the atlas file take precedence over these, and are reloaded whenever it changes
+		cortex.Synapses() <- neural.Net.Vanities(life.Looping, "git.ignitelabs.net", ":"+port, []neural.VanityMapping{
+			{Prefix: "git.ignitelabs.net", Remote: "https://github.com/ignite-laboratories", Nested: true},
+		}, func(imp *std.Impulse) {
 			cortex.Impulse()
 		})
 